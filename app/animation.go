@@ -0,0 +1,145 @@
+package app
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/alexanderbh/bubbleapp/style"
+)
+
+// animationInterval is how often a moving animation is rendered.
+const animationInterval = 16 * time.Millisecond
+
+// animationFrame renders the component again on the next tick. It is called
+// in every final render while something moves so the tick listener is gone
+// as soon as it stops. The listener has its own id so it does not share the
+// last tick with a UseTick of the component.
+func animationFrame(c *Ctx) {
+	if c.LayoutPhase == LayoutPhaseFinalRender {
+		c.tick.register(animationInterval, c.id.getID()+"#anim", c.Update)
+	}
+}
+
+type animatedValue[T any] struct {
+	target T
+	from   T
+	value  T
+}
+
+// UseAnimatedValue returns a value which moves to the target over the
+// duration with the easing when the target changes. It starts at the target.
+func UseAnimatedValue(c *Ctx, target float64, duration time.Duration, easing Easing) float64 {
+	progress := UseTransition(c, target, duration)
+	state, _ := UseState(c, &animatedValue[float64]{target: target, from: target, value: target})
+	if state.target != target {
+		state.from, state.target = state.value, target
+	}
+	state.value = state.from + (state.target-state.from)*easing(progress)
+	return state.value
+}
+
+// UseAnimatedColor returns a color which blends to the target over the
+// duration with the easing when the target changes, like for highlights.
+func UseAnimatedColor(c *Ctx, target color.Color, duration time.Duration, easing Easing) color.Color {
+	key := colorKey(target)
+	progress := UseTransition(c, key, duration)
+	state, _ := UseState(c, &animatedValue[color.Color]{target: target, from: target, value: target})
+	if colorKey(state.target) != key {
+		state.from, state.target = state.value, target
+	}
+	if state.from == nil {
+		state.from = target
+	}
+	state.value = style.Blend(state.from, state.target, easing(progress))
+	return state.value
+}
+
+// colorKey makes the color comparable.
+func colorKey(c color.Color) [4]uint32 {
+	if c == nil {
+		return [4]uint32{}
+	}
+	r, g, b, a := c.RGBA()
+	return [4]uint32{r, g, b, a}
+}
+
+// Spring is how a spring moves to its target.
+type Spring struct {
+	// Frequency is how fast the spring moves. Higher is faster.
+	Frequency float64
+	// Damping slows the spring down. Below 1 it overshoots the target and
+	// bounces, 1 and above it does not.
+	Damping float64
+}
+
+// DefaultSpring moves quickly with a small bounce.
+var DefaultSpring = Spring{Frequency: 10, Damping: 0.7}
+
+// springRest is how close to the target the spring stops.
+const springRest = 0.001
+
+type springState struct {
+	value    float64
+	velocity float64
+	last     time.Time
+}
+
+// UseSpring returns a value which follows the target with spring physics.
+// It keeps its velocity when the target changes while it moves. It starts at
+// the target.
+func UseSpring(c *Ctx, target float64, spring Spring) float64 {
+	state, _ := UseState(c, &springState{value: target})
+
+	// The spring moves once per render so all layout phases agree.
+	if c.LayoutPhase == LayoutPhaseIntrincintWidth {
+		now := time.Now()
+		if state.value != target {
+			// A long pause, like when it starts, counts as a few frames so the
+			// spring does not jump.
+			dt := min(now.Sub(state.last), 2*animationInterval).Seconds()
+			state.value, state.velocity = spring.step(state.value, state.velocity, target, dt)
+			if math.Abs(state.value-target) < springRest && math.Abs(state.velocity) < springRest {
+				state.value, state.velocity = target, 0
+			}
+		}
+		state.last = now
+	}
+
+	if state.value != target {
+		animationFrame(c)
+	}
+	return state.value
+}
+
+// step moves the spring by dt seconds with the exact solution of a damped
+// harmonic oscillator, so it is stable with any frame time.
+func (s Spring) step(value, velocity, target, dt float64) (float64, float64) {
+	omega, zeta := s.Frequency, max(0, s.Damping)
+	x := value - target
+	switch {
+	case zeta > 1+1e-4:
+		// Over-damped
+		za := -omega * zeta
+		zb := omega * math.Sqrt(zeta*zeta-1)
+		z1, z2 := za-zb, za+zb
+		e1, e2 := math.Exp(z1*dt), math.Exp(z2*dt)
+		c1 := (velocity - x*z2) / (z1 - z2)
+		c2 := x - c1
+		x, velocity = c1*e1+c2*e2, c1*z1*e1+c2*z2*e2
+	case zeta < 1-1e-4:
+		// Under-damped
+		omegaZeta := omega * zeta
+		alpha := omega * math.Sqrt(1-zeta*zeta)
+		e := math.Exp(-omegaZeta * dt)
+		cos, sin := math.Cos(alpha*dt), math.Sin(alpha*dt)
+		c2 := (velocity + x*omegaZeta) / alpha
+		x, velocity = e*(x*cos+c2*sin), e*((c2*alpha-x*omegaZeta)*cos-(x*alpha+c2*omegaZeta)*sin)
+	default:
+		// Critically damped
+		e := math.Exp(-omega * dt)
+		c2 := velocity + omega*x
+		x, velocity = e*(x+c2*dt), e*(c2-omega*(x+c2*dt))
+	}
+	return target + x, velocity
+}
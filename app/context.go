@@ -19,8 +19,9 @@ type Ctx struct {
 	teaProgram    *tea.Program
 	Theme         *style.AppTheme
 	id            *idContext
-	tick          *tickState[any]
+	tick          *scheduler
 	invalidate    bool
+	inFrame       bool
 	components    map[string]*C
 	ids           []string
 	contextValues map[uint64][]any // Added for Context API
@@ -32,6 +33,11 @@ type Ctx struct {
 	layoutManager *layoutManager
 
 	Cursor *tea.Cursor
+
+	overlays  []overlay
+	selection selectionState
+	keptAlive map[string]struct{}
+	lifecycle lifecycle
 }
 
 func NewCtx() *Ctx {
@@ -41,11 +47,14 @@ func NewCtx() *Ctx {
 		zoneMap:       make(map[string]*C),
 		Theme:         style.NewDefaultAppTheme(),
 		id:            newIdContext(),
-		tick:          &tickState[any]{},
+		tick:          newScheduler(),
 		components:    make(map[string]*C),
 		ids:           make([]string, 0),
 		layoutManager: newLayoutManager(),
 		contextValues: make(map[uint64][]any),
+		selection:     selectionState{key: defaultSelectionKey()},
+		keptAlive:     make(map[string]struct{}),
+		lifecycle:     lifecycle{focused: true},
 	}
 }
 
@@ -56,6 +65,12 @@ func (c *Ctx) RenderWithName(fn func(c *Ctx, props Props) string, props Props, n
 	var comp *C = c.components[id]
 	if comp == nil || c.LayoutPhase == LayoutPhaseIntrincintWidth {
 		comp = c.initComponent(id, props)
+	} else if c.LayoutPhase == LayoutPhaseIntrincintHeight {
+		// A fixed size may depend on the size of the parent which is only
+		// known from this phase.
+		layout := extractLayoutFromProps(props)
+		comp.layout.Width = layout.Width
+		comp.layout.Height = layout.Height
 	}
 
 	c.ids = append(c.ids, id)
@@ -90,6 +105,8 @@ func (c *Ctx) initView() {
 	c.tick.init()
 	c.zoneMap = make(map[string]*C)
 	c.Cursor = nil
+	c.overlays = nil
+	c.lifecycle.reset()
 
 	c.ids = []string{}
 	for _, cs := range c.components {
@@ -98,6 +115,9 @@ func (c *Ctx) initView() {
 		cs.messageHandlers = make([]MsgHandler, 0)
 		cs.globalKeyHandlers = make([]KeyHandler, 0)
 		cs.onFocused = nil
+		// Focusable is set again by the hooks so a component which only
+		// handles keys some of the time is only focusable then.
+		cs.focusable = false
 		cs.height = 0
 		cs.width = 0
 
@@ -188,7 +208,8 @@ func (c *Ctx) Update() {
 	if c.teaProgram == nil {
 		panic("teaProgram is nil. Cannot update manually.")
 	}
-	if !c.invalidate {
+	// The frame of the ticks is rendered anyway.
+	if !c.invalidate && !c.inFrame {
 		if c.teaProgram != nil {
 			go c.teaProgram.Send(InvalidateMsg{})
 		}
@@ -219,10 +240,38 @@ func (c *Ctx) ExecuteCmd(cmd tea.Cmd) {
 	}
 }
 
+// asyncResultMsg carries the result of work started with Go back to the
+// update loop.
+type asyncResultMsg func()
+
+// Go runs work in a goroutine. The function returned by work is called on the
+// update loop where it is safe to change state, and the view is updated after.
+func (c *Ctx) Go(work func() func()) {
+	if c.teaProgram == nil {
+		panic("teaProgram is nil. Cannot run async work.")
+	}
+	go func() {
+		if apply := work(); apply != nil {
+			c.teaProgram.Send(asyncResultMsg(apply))
+		}
+	}()
+}
+
 // Quit signals the application to stop, ensuring cleanup like stopping active timers.
+// A UseBeforeQuit handler can keep it running.
 func (ctx *Ctx) Quit() {
+	for _, handler := range ctx.lifecycle.onBeforeQuit {
+		if !handler() {
+			return
+		}
+	}
+	ctx.ForceQuit()
+}
+
+// ForceQuit stops the application without asking the UseBeforeQuit handlers.
+func (ctx *Ctx) ForceQuit() {
 	if ctx.tick != nil {
-		ctx.tick.StopActiveTimer()
+		ctx.tick.stop()
 	}
 	go ctx.teaProgram.Quit()
 }
@@ -119,18 +119,29 @@ func UseState[T any](c *Ctx, initialValue T) (T, func(valueOrUpdater interface{}
 }
 
 // UseTick schedules a function to be called at a specified interval.
-// The callback will be invoked in a separate goroutine managed by the tick system.
+// The callback is called on the update loop, so it can change state, and all
+// callbacks of a tick frame are rendered once. Ticks run at most at the max
+// FPS of the app and pause while the terminal does not have focus.
+//
+// A nil callback does not tick, so a component can stop ticking without
+// calling its hooks conditionally.
+//
+// IMPORTANT: Use intervals with a large common divisor. Frames happen at the
+// greatest common divisor of all intervals, so 80ms and 100ms means a frame
+// every 20ms.
 func UseTick(c *Ctx, interval time.Duration, callback func()) {
 	if c.LayoutPhase != LayoutPhaseFinalRender {
 		return
 	}
 	instanceID := c.id.getID()
-	c.tick.RegisterTickListener(interval, instanceID, callback)
+	if callback != nil {
+		c.tick.register(interval, instanceID, callback)
+	}
 	UseEffectWithCleanup(c, func() func() {
 		// Return the cleanup function.
 		return func() {
 			if c.tick != nil {
-				c.tick.UnregisterTickListener(instanceID)
+				c.tick.unregister(instanceID)
 			}
 		}
 	}, []any{})
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"slices"
+	"strings"
+)
+
+// KeepAlive keeps the state of the component with the id and the components
+// below it while they are not rendered, instead of removing them. It is kept
+// until ReleaseKeepAlive is called.
+func (c *Ctx) KeepAlive(id string) {
+	c.keptAlive[id] = struct{}{}
+}
+
+// ReleaseKeepAlive stops keeping the component with the id. If it is not
+// rendered it is removed with the components below it right away.
+func (c *Ctx) ReleaseKeepAlive(id string) {
+	delete(c.keptAlive, id)
+	if slices.Contains(c.ids, id) {
+		return
+	}
+	var removed []string
+	for componentID := range c.components {
+		if isBelow(componentID, id) {
+			removed = append(removed, componentID)
+		}
+	}
+	c.cleanupEffects(removed)
+}
+
+// withoutKeptAlive returns the removed IDs which are not kept alive. The
+// components of a kept alive component which is still rendered are removed
+// as usual.
+func (c *Ctx) withoutKeptAlive(removedIDs []string, currentIDs []string) []string {
+	if len(c.keptAlive) == 0 {
+		return removedIDs
+	}
+	hidden := make([]string, 0, len(c.keptAlive))
+	for id := range c.keptAlive {
+		if !slices.Contains(currentIDs, id) {
+			hidden = append(hidden, id)
+		}
+	}
+	return slices.DeleteFunc(removedIDs, func(removedID string) bool {
+		return slices.ContainsFunc(hidden, func(id string) bool {
+			return isBelow(removedID, id)
+		})
+	})
+}
+
+// isBelow reports whether the component with the id is the root or one of
+// the components below it.
+func isBelow(id string, root string) bool {
+	return id == root || strings.HasPrefix(id, root+"_")
+}
@@ -12,8 +12,16 @@ type Layout struct {
 	GrowY     bool
 	GapX      int
 	GapY      int
-	Width     int
-	Height    int
+	// Width and Height fix the size of a component that does not grow.
+	Width  int
+	Height int
+	// Weight is the share of the remaining space a growing component gets
+	// relative to its growing siblings. Zero is treated as 1.
+	Weight int
+	// MinWidth and MinHeight are the smallest sizes a growing component
+	// is shrunk to when the remaining space is distributed.
+	MinWidth  int
+	MinHeight int
 }
 
 type LayoutDirection int
@@ -61,14 +69,24 @@ func (lm *layoutManager) pop(c *Ctx, comp *C) {
 		if c.LayoutPhase == LayoutPhaseIntrincintWidth {
 			if comp.parent == nil {
 				comp.width = lm.width
+			} else if !comp.layout.GrowX && comp.layout.Width > 0 {
+				comp.width = comp.layout.Width
 			} else if !comp.layout.GrowX {
 				width := lipgloss.Width(comp.String())
 				comp.width = width
 			}
 		}
 		if c.LayoutPhase == LayoutPhaseIntrincintHeight {
+			// The fixed width may have changed since the first phase so the
+			// children get their widths again.
+			if comp.parent != nil && !comp.layout.GrowX && comp.layout.Width > 0 && comp.width != comp.layout.Width {
+				comp.width = comp.layout.Width
+				Visit(comp, 0, c, distributeAvailableWidthVisitor, PreOrder)
+			}
 			if comp.parent == nil {
 				comp.height = lm.height
+			} else if !comp.layout.GrowY && comp.layout.Height > 0 {
+				comp.height = comp.layout.Height
 			} else if !comp.layout.GrowY {
 				if comp.String() == "" {
 					comp.height = 0
@@ -162,6 +180,11 @@ func extractLayoutFromProps(props interface{}) Layout {
 				mergedLayout.GrowY = layout.GrowY
 				mergedLayout.GapX = layout.GapX
 				mergedLayout.GapY = layout.GapY
+				mergedLayout.Width = layout.Width
+				mergedLayout.Height = layout.Height
+				mergedLayout.Weight = layout.Weight
+				mergedLayout.MinWidth = layout.MinWidth
+				mergedLayout.MinHeight = layout.MinHeight
 				return mergedLayout
 			}
 		}
@@ -255,15 +278,14 @@ func distributeAvailableWidthVisitor(node *C, _ int, c *Ctx) {
 		}
 
 		if growingChildrenCount > 0 {
-			baseWidth := remainingWidth / growingChildrenCount
-			remainder := remainingWidth % growingChildrenCount
-
+			weights := make([]int, growingChildrenCount)
+			mins := make([]int, growingChildrenCount)
 			for i, child := range growingChildren {
-				childWidth := baseWidth
-				if i < remainder {
-					childWidth++
-				}
-				child.width = childWidth
+				weights[i] = child.layout.Weight
+				mins[i] = child.layout.MinWidth
+			}
+			for i, childWidth := range distributeGrowth(remainingWidth, weights, mins) {
+				growingChildren[i].width = childWidth
 			}
 		}
 	}
@@ -312,16 +334,77 @@ func distributeAvailableHeightVisitor(node *C, _ int, ctx *Ctx) {
 		}
 
 		if growingChildrenCount > 0 {
-			baseHeight := remainingHeight / growingChildrenCount
-			remainder := remainingHeight % growingChildrenCount
-
+			weights := make([]int, growingChildrenCount)
+			mins := make([]int, growingChildrenCount)
 			for i, child := range growingChildren {
-				childHeight := baseHeight
-				if i < remainder {
-					childHeight++
-				}
-				child.height = childHeight
+				weights[i] = child.layout.Weight
+				mins[i] = child.layout.MinHeight
+			}
+			for i, childHeight := range distributeGrowth(remainingHeight, weights, mins) {
+				growingChildren[i].height = childHeight
+			}
+		}
+	}
+}
+
+// distributeGrowth splits the available space between growing children
+// according to their weights. Children whose share would fall below their
+// minimum size are given the minimum and the rest is split between the others.
+// Cells left over from rounding go to the first children.
+func distributeGrowth(available int, weights []int, mins []int) []int {
+	sizes := make([]int, len(weights))
+	fixed := make([]bool, len(weights))
+	for i := range weights {
+		if weights[i] <= 0 {
+			weights[i] = 1
+		}
+	}
+
+	for {
+		remaining := available
+		totalWeight := 0
+		for i := range weights {
+			if fixed[i] {
+				remaining -= sizes[i]
+			} else {
+				totalWeight += weights[i]
+			}
+		}
+		if totalWeight == 0 {
+			return sizes
+		}
+		if remaining < 0 {
+			remaining = 0
+		}
+
+		changed := false
+		for i := range weights {
+			if !fixed[i] && remaining*weights[i]/totalWeight < mins[i] {
+				sizes[i] = mins[i]
+				fixed[i] = true
+				changed = true
+			}
+		}
+		if changed {
+			continue
+		}
+
+		used := 0
+		for i := range weights {
+			if !fixed[i] {
+				sizes[i] = remaining * weights[i] / totalWeight
+				used += sizes[i]
+			}
+		}
+		for i := range weights {
+			if used >= remaining {
+				break
+			}
+			if !fixed[i] {
+				sizes[i]++
+				used++
 			}
 		}
+		return sizes
 	}
 }
@@ -0,0 +1,101 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// lifecycle holds the handlers of the app lifecycle hooks. They are
+// registered again in every final render.
+type lifecycle struct {
+	focused    bool
+	windowSize tea.WindowSizeMsg
+
+	onFocus      []func(focused bool)
+	onSuspend    []func()
+	onResume     []func()
+	onBeforeQuit []func() bool
+}
+
+// reset removes the handlers before a render registers them again.
+func (l *lifecycle) reset() {
+	l.onFocus = nil
+	l.onSuspend = nil
+	l.onResume = nil
+	l.onBeforeQuit = nil
+}
+
+// UseAppFocus returns whether the terminal has focus and calls the handler
+// when it gets or loses focus. The terminal only reports it when the program
+// is started with tea.WithReportFocus. The handler can be nil.
+func UseAppFocus(c *Ctx, handler func(focused bool)) bool {
+	if handler != nil && c.LayoutPhase == LayoutPhaseFinalRender {
+		c.lifecycle.onFocus = append(c.lifecycle.onFocus, handler)
+	}
+	return c.lifecycle.focused
+}
+
+// UseSuspend calls the handler before the app is suspended with ctrl+z or
+// c.Suspend.
+func UseSuspend(c *Ctx, handler func()) {
+	if c.LayoutPhase == LayoutPhaseFinalRender {
+		c.lifecycle.onSuspend = append(c.lifecycle.onSuspend, handler)
+	}
+}
+
+// UseResume calls the handler when the app is resumed after it was
+// suspended.
+func UseResume(c *Ctx, handler func()) {
+	if c.LayoutPhase == LayoutPhaseFinalRender {
+		c.lifecycle.onResume = append(c.lifecycle.onResume, handler)
+	}
+}
+
+// UseBeforeQuit calls the handler when the app is about to quit with ctrl+c
+// or c.Quit. Returning false keeps the app running, like to ask the user
+// first and then quit with c.ForceQuit.
+func UseBeforeQuit(c *Ctx, handler func() bool) {
+	if c.LayoutPhase == LayoutPhaseFinalRender {
+		c.lifecycle.onBeforeQuit = append(c.lifecycle.onBeforeQuit, handler)
+	}
+}
+
+// UseWindowSize returns the latest size of the terminal.
+func UseWindowSize(c *Ctx) tea.WindowSizeMsg {
+	return c.lifecycle.windowSize
+}
+
+// Suspend suspends the app like ctrl+z. The UseSuspend handlers are called
+// first.
+func (c *Ctx) Suspend() {
+	for _, handler := range c.lifecycle.onSuspend {
+		handler()
+	}
+	c.tick.pause(true)
+	c.ExecuteCmd(tea.Suspend)
+}
+
+// handleLifecycle updates the lifecycle state with the message and calls the
+// handlers.
+func (c *Ctx) handleLifecycle(msg tea.Msg) {
+	switch msg := msg.(type) {
+	case tea.FocusMsg, tea.BlurMsg:
+		_, focused := msg.(tea.FocusMsg)
+		c.lifecycle.focused = focused
+		// Ticks pause while the terminal does not have focus.
+		c.tick.pause(!focused)
+		for _, handler := range c.lifecycle.onFocus {
+			handler(focused)
+		}
+	case tea.SuspendMsg:
+		// It arrives once the app runs again, or right away where suspending
+		// is not supported.
+		c.tick.pause(!c.lifecycle.focused)
+	case tea.ResumeMsg:
+		c.tick.pause(!c.lifecycle.focused)
+		for _, handler := range c.lifecycle.onResume {
+			handler()
+		}
+	case tea.WindowSizeMsg:
+		c.lifecycle.windowSize = msg
+	}
+}
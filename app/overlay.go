@@ -0,0 +1,255 @@
+package app
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Rect is an area on the screen.
+type Rect struct {
+	X, Y          int
+	Width, Height int
+}
+
+// Contains reports whether the cell at x, y is inside the area.
+func (r Rect) Contains(x, y int) bool {
+	return x >= r.X && x < r.X+r.Width && y >= r.Y && y < r.Y+r.Height
+}
+
+// Overlay is content drawn on top of the view after everything else has been
+// rendered. Mouse events inside an overlay only go to the component that owns
+// it, so mouse zones in the content should be marked by the owner.
+type Overlay struct {
+	Content string
+	// Anchor is the area the overlay is placed next to. The overlay is placed
+	// on the side of the anchor given by Placement, or on the opposite side
+	// when there is no room.
+	Anchor    Rect
+	Placement Placement
+	// OnDismiss is called when the mouse is clicked outside the overlays and
+	// the component owning it.
+	OnDismiss func()
+	// Corner places the overlay in a corner of the screen instead of next to
+	// the anchor.
+	Corner Corner
+}
+
+// Placement is the side of the anchor an overlay is placed on.
+type Placement int
+
+const (
+	Below Placement = iota
+	Above
+	Right
+	Left
+)
+
+// Corner is a corner of the screen.
+type Corner int
+
+const (
+	NoCorner Corner = iota
+	TopLeft
+	TopRight
+	BottomLeft
+	BottomRight
+)
+
+// overlay is an Overlay as it was placed on the screen.
+type overlay struct {
+	Overlay
+	ownerID string
+	bounds  Rect
+}
+
+// UseOverlay draws the overlay on top of the view for this render.
+// Overlays registered later are drawn on top of earlier ones.
+func UseOverlay(c *Ctx, o Overlay) {
+	if c.LayoutPhase != LayoutPhaseFinalRender || o.Content == "" {
+		return
+	}
+	c.overlays = append(c.overlays, overlay{Overlay: o, ownerID: c.id.getID()})
+}
+
+// UseBounds returns the area of the component on the screen as it was last
+// drawn. Before the component has been drawn the area is based on the layout.
+func UseBounds(c *Ctx) Rect {
+	if bounds, ok := zoneBounds(c, c.id.getID()); ok {
+		return bounds
+	}
+	instance := c.getCurrentComponent()
+	return Rect{X: instance.x, Y: instance.y, Width: instance.width, Height: instance.height}
+}
+
+// UseChildBounds returns the area of a child mouse zone of the component as
+// it was last drawn. It is false when the zone has not been drawn, like
+// content in an overlay which is shown for the first time.
+func UseChildBounds(c *Ctx, childID string) (Rect, bool) {
+	return zoneBounds(c, c.id.getID()+"###"+childID)
+}
+
+func zoneBounds(c *Ctx, id string) (Rect, bool) {
+	zone := c.zone.Get(id)
+	if zone.IsZero() {
+		return Rect{}, false
+	}
+	return Rect{
+		X:      zone.StartX,
+		Y:      zone.StartY,
+		Width:  zone.EndX - zone.StartX + 1,
+		Height: zone.EndY - zone.StartY + 1,
+	}, true
+}
+
+// drawOverlays places the overlays registered during the render on the view.
+func (c *Ctx) drawOverlays(view string) string {
+	if len(c.overlays) == 0 {
+		return view
+	}
+	lines := strings.Split(view, "\n")
+	screenWidth, screenHeight := c.layoutManager.width, c.layoutManager.height
+	for i := range c.overlays {
+		o := &c.overlays[i]
+		content := strings.Split(o.Content, "\n")
+		width, height := lipgloss.Width(o.Content), len(content)
+
+		a := o.Anchor
+		x, y := a.X, a.Y+a.Height
+		switch o.Placement {
+		case Below:
+			if y+height > screenHeight && a.Y-height >= 0 {
+				y = a.Y - height
+			}
+		case Above:
+			y = a.Y - height
+			if y < 0 && a.Y+a.Height+height <= screenHeight {
+				y = a.Y + a.Height
+			}
+		case Right:
+			x, y = a.X+a.Width, a.Y
+			if x+width > screenWidth && a.X-width >= 0 {
+				x = a.X - width
+			}
+		case Left:
+			x, y = a.X-width, a.Y
+			if x < 0 && a.X+a.Width+width <= screenWidth {
+				x = a.X + a.Width
+			}
+		}
+		x = min(x, screenWidth-width)
+		switch o.Corner {
+		case TopLeft:
+			x, y = 0, 0
+		case TopRight:
+			x, y = screenWidth-width, 0
+		case BottomLeft:
+			x, y = 0, screenHeight-height
+		case BottomRight:
+			x, y = screenWidth-width, screenHeight-height
+		}
+		x, y = max(x, 0), max(min(y, screenHeight-height), 0)
+		o.bounds = Rect{X: x, Y: y, Width: width, Height: height}
+
+		for j, line := range content {
+			for y+j >= len(lines) {
+				lines = append(lines, "")
+			}
+			lines[y+j] = spliceLine(lines[y+j], line, x, width)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// spliceLine replaces the cells of base from x with top. Escape sequences
+// under top, like mouse zone markers, are kept and moved after it, and the
+// styles of base are restored after top.
+func spliceLine(base string, top string, x int, width int) string {
+	var left, hidden, right, styles strings.Builder
+	col := 0
+	state := ansi.NormalState
+	for len(base) > 0 {
+		seq, w, n, newState := ansi.DecodeSequence(base, state, nil)
+		state = newState
+		base = base[n:]
+
+		if w == 0 {
+			switch {
+			// A zone marker right at x, like the end of an anchor with top
+			// placed right of it, is kept where it is.
+			case col <= x:
+				left.WriteString(seq)
+				if isSGR(seq) {
+					styles.WriteString(seq)
+				}
+			case col < x+width:
+				hidden.WriteString(seq)
+			default:
+				right.WriteString(seq)
+			}
+			continue
+		}
+
+		switch {
+		case col+w <= x:
+			left.WriteString(seq)
+		case col < x:
+			// A wide character cut by the left edge of top.
+			left.WriteString(strings.Repeat(" ", x-col))
+		case col >= x+width:
+			right.WriteString(seq)
+		case col+w > x+width:
+			// A wide character cut by the right edge of top.
+			right.WriteString(strings.Repeat(" ", col+w-x-width))
+		}
+		col += w
+	}
+	if col < x {
+		left.WriteString(strings.Repeat(" ", x-col))
+	}
+
+	topWidth := ansi.StringWidth(top)
+	return left.String() + ansi.ResetStyle + top + strings.Repeat(" ", max(width-topWidth, 0)) +
+		ansi.ResetStyle + styles.String() + hidden.String() + right.String()
+}
+
+func isSGR(seq string) bool {
+	return strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "m")
+}
+
+// overlayAt returns the topmost overlay containing the mouse.
+func (c *Ctx) overlayAt(mouse tea.Mouse) *overlay {
+	for i := len(c.overlays) - 1; i >= 0; i-- {
+		if c.overlays[i].bounds.Contains(mouse.X, mouse.Y) {
+			return &c.overlays[i]
+		}
+	}
+	return nil
+}
+
+// dismissOverlays calls OnDismiss of the overlays when the mouse is clicked
+// outside of them and the components owning them. A click in another overlay
+// of the same owner, like a submenu, does not dismiss.
+func (c *Ctx) dismissOverlays(mouse tea.Mouse) {
+	for _, o := range c.overlays {
+		if o.OnDismiss == nil {
+			continue
+		}
+		if inside := c.overlayAt(mouse); inside != nil && inside.ownerID == o.ownerID {
+			continue
+		}
+		if zone := c.zone.Get(o.ownerID); !zone.IsZero() &&
+			mouse.X >= zone.StartX && mouse.X <= zone.EndX && mouse.Y >= zone.StartY && mouse.Y <= zone.EndY {
+			continue
+		}
+		o.OnDismiss()
+	}
+}
+
+// ownedBy reports whether the component with the id is the owner or one of
+// its children.
+func (o *overlay) ownedBy(id string) bool {
+	return id == o.ownerID || strings.HasPrefix(id, o.ownerID+"_")
+}
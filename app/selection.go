@@ -0,0 +1,200 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// selectionState holds the text selection of the screen. While selection
+// mode is on the mouse selects text instead of being sent to components.
+type selectionState struct {
+	key      key.Binding
+	mode     bool
+	dragging bool
+	// rect selects a rectangle instead of flowing from line to line.
+	rect       bool
+	start, end tea.Position
+	selected   bool
+	// frame is the last rendered view the selection is taken from.
+	frame []string
+}
+
+func defaultSelectionKey() key.Binding {
+	return key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "select text"))
+}
+
+// WithSelectionKey sets the key turning selection mode on and off. The
+// default is ctrl+s.
+func WithSelectionKey(binding key.Binding) AppOption {
+	return func(opts *AppOptions) {
+		opts.SelectionKey = &binding
+	}
+}
+
+// SetSelectionMode turns selection mode on or off. In selection mode dragging
+// the mouse selects text on the screen which is copied to the clipboard when
+// the mouse is released. Hold alt while dragging to select a rectangle.
+func (c *Ctx) SetSelectionMode(on bool) {
+	c.selection.mode = on
+	c.selection.dragging = false
+	c.selection.selected = false
+	c.Update()
+}
+
+// SelectionMode reports whether selection mode is on.
+func (c *Ctx) SelectionMode() bool {
+	return c.selection.mode
+}
+
+// SelectedText returns the plain text of the selection on the screen.
+func (c *Ctx) SelectedText() string {
+	s := &c.selection
+	if !s.selected {
+		return ""
+	}
+	top, bottom := s.rows()
+	lines := make([]string, 0, bottom-top+1)
+	for y := top; y <= bottom && y < len(s.frame); y++ {
+		plain := ansi.Strip(s.frame[y])
+		from, to := s.columns(y, ansi.StringWidth(plain))
+		lines = append(lines, strings.TrimRight(ansi.Cut(plain, from, to), " "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// handleSelectionKey turns selection mode on and off.
+func (c *Ctx) handleSelectionKey(msg tea.KeyMsg) bool {
+	if key.Matches(msg, c.selection.key) {
+		c.SetSelectionMode(!c.selection.mode)
+		return true
+	}
+	if c.selection.mode && msg.String() == "esc" {
+		c.SetSelectionMode(false)
+		return true
+	}
+	return false
+}
+
+// handleSelectionMouse selects text while selection mode is on. The text is
+// copied to the clipboard when the mouse is released. The mouse wheel is left
+// to the components so the content can be scrolled.
+func (c *Ctx) handleSelectionMouse(msg tea.MouseMsg) (bool, tea.Cmd) {
+	s := &c.selection
+	if !s.mode {
+		return false, nil
+	}
+	mouse := msg.Mouse()
+	switch msg.(type) {
+	case tea.MouseClickMsg:
+		if mouse.Button != tea.MouseLeft {
+			return true, nil
+		}
+		s.dragging, s.selected = true, true
+		s.rect = mouse.Mod.Contains(tea.ModAlt)
+		s.start = tea.Position{X: mouse.X, Y: mouse.Y}
+		s.end = s.start
+	case tea.MouseMotionMsg:
+		if !s.dragging {
+			return true, nil
+		}
+		s.end = tea.Position{X: mouse.X, Y: mouse.Y}
+	case tea.MouseReleaseMsg:
+		if !s.dragging {
+			return true, nil
+		}
+		s.dragging = false
+		s.end = tea.Position{X: mouse.X, Y: mouse.Y}
+		c.Update()
+		if text := c.SelectedText(); text != "" {
+			return true, tea.SetClipboard(text)
+		}
+		return true, nil
+	default:
+		return false, nil
+	}
+	c.Update()
+	return true, nil
+}
+
+// rows returns the first and the last line of the selection.
+func (s *selectionState) rows() (int, int) {
+	return min(s.start.Y, s.end.Y), max(s.start.Y, s.end.Y)
+}
+
+// columns returns the cells of line y in the selection. width is the width
+// of the line.
+func (s *selectionState) columns(y int, width int) (int, int) {
+	if s.rect {
+		return min(s.start.X, s.end.X), max(s.start.X, s.end.X) + 1
+	}
+	first, last := s.start, s.end
+	if last.Y < first.Y || (last.Y == first.Y && last.X < first.X) {
+		first, last = last, first
+	}
+	from, to := 0, width
+	if y == first.Y {
+		from = first.X
+	}
+	if y == last.Y {
+		to = last.X + 1
+	}
+	return from, to
+}
+
+// drawSelection keeps the view for SelectedText and highlights the selection
+// on it.
+func (c *Ctx) drawSelection(view string) string {
+	s := &c.selection
+	s.frame = strings.Split(view, "\n")
+	if !s.mode {
+		return view
+	}
+
+	highlight := c.Theme.Selection
+
+	lines := strings.Split(view, "\n")
+	if s.selected {
+		top, bottom := s.rows()
+		for y := top; y <= bottom && y < len(lines); y++ {
+			plain := ansi.Strip(lines[y])
+			from, to := s.columns(y, ansi.StringWidth(plain))
+			if from >= to {
+				continue
+			}
+			lines[y] = spliceLine(lines[y], highlight.Render(ansi.Cut(plain, from, to)), from, to-from)
+		}
+	}
+
+	label := highlight.Bold(true).Render(" SELECT ")
+	if last := len(lines) - 1; last >= 0 {
+		width := lipgloss.Width(label)
+		lines[last] = spliceLine(lines[last], label, max(c.layoutManager.width-width, 0), width)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Clipboard copies text to and pastes text from the system clipboard. It uses
+// OSC52 which works over SSH but is not supported by all terminals.
+type Clipboard struct {
+	c *Ctx
+}
+
+// UseClipboard returns the clipboard of the terminal.
+func UseClipboard(c *Ctx) Clipboard {
+	return Clipboard{c: c}
+}
+
+// Copy puts the text on the clipboard.
+func (cb Clipboard) Copy(text string) {
+	cb.c.ExecuteCmd(tea.SetClipboard(text))
+}
+
+// Paste asks the terminal for the clipboard. The text arrives as a
+// tea.ClipboardMsg to the focused component.
+func (cb Clipboard) Paste() {
+	cb.c.ExecuteCmd(tea.ReadClipboard)
+}
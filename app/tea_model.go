@@ -1,9 +1,11 @@
 package app
 
 import (
+	"slices"
 	"strings"
 
 	"github.com/alexanderbh/bubbleapp/style"
+	"github.com/charmbracelet/bubbles/v2/key"
 	tea "github.com/charmbracelet/bubbletea/v2"
 )
 
@@ -17,7 +19,9 @@ type FCs = func(c *Ctx) []*C
 type FC = func(c *Ctx) *C
 
 type AppOptions struct {
-	Theme *style.AppTheme
+	Theme        *style.AppTheme
+	SelectionKey *key.Binding
+	MaxFPS       int
 }
 type AppOption func(*AppOptions)
 
@@ -27,6 +31,13 @@ func WithTheme(theme *style.AppTheme) AppOption {
 	}
 }
 
+// WithMaxFPS limits how often ticks and animations run. It is 60 by default.
+func WithMaxFPS(fps int) AppOption {
+	return func(opts *AppOptions) {
+		opts.MaxFPS = fps
+	}
+}
+
 type app struct {
 	root FC
 	ctx  *Ctx
@@ -41,6 +52,12 @@ func New(ctx *Ctx, root FC, options ...AppOption) *app {
 	if opts.Theme != nil {
 		ctx.Theme = opts.Theme
 	}
+	if opts.SelectionKey != nil {
+		ctx.selection.key = *opts.SelectionKey
+	}
+	if opts.MaxFPS > 0 {
+		ctx.tick.maxFPS = opts.MaxFPS
+	}
 
 	return &app{
 		root: root,
@@ -69,10 +86,21 @@ func (a *app) Init() tea.Cmd {
 func (a *app) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// TODO: Add debug logging flag
 	//log.Println("UPDATE", msg)
+	a.ctx.handleLifecycle(msg)
+
 	switch msg := msg.(type) {
 	case InvalidateMsg:
 		return a, nil
+	case frameMsg:
+		a.ctx.tick.frame(a.ctx, msg.at)
+		return a, nil
+	case asyncResultMsg:
+		msg()
+		return a, nil
 	case tea.KeyMsg:
+		if a.ctx.handleSelectionKey(msg) {
+			return a, nil
+		}
 		focusedInstance, focusedInstanceExists := a.ctx.getComponent(a.ctx.UIState.Focused)
 		if focusedInstanceExists {
 			for _, handler := range focusedInstance.keyHandlers {
@@ -95,6 +123,9 @@ func (a *app) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "ctrl+c":
 			a.ctx.Quit()
 			return a, nil
+		case "ctrl+z":
+			a.ctx.Suspend()
+			return a, nil
 		case "tab":
 			a.ctx.FocusNext()
 			return a, nil
@@ -108,7 +139,28 @@ func (a *app) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.ctx.layoutManager.height = msg.Height
 		return a, nil
 	case tea.MouseMsg:
+		if handled, cmd := a.ctx.handleSelectionMouse(msg); handled {
+			return a, cmd
+		}
 		idsInBounds := a.ctx.zone.IDsInBounds(msg)
+		if clickMsg, ok := msg.(tea.MouseClickMsg); ok {
+			a.ctx.dismissOverlays(clickMsg.Mouse())
+		}
+		// Only the owner of an overlay gets the events inside it.
+		if o := a.ctx.overlayAt(msg.Mouse()); o != nil {
+			owned := idsInBounds[:0]
+			for _, id := range idsInBounds {
+				if o.ownedBy(strings.Split(id, "###")[0]) {
+					owned = append(owned, id)
+				}
+			}
+			idsInBounds = owned
+		}
+		// The most specific zone gets the event first. Longer IDs are deeper in
+		// the tree and a child zone is more specific than its component.
+		slices.SortStableFunc(idsInBounds, func(a, b string) int {
+			return len(b) - len(a)
+		})
 		_, isMotionMsg := msg.(tea.MouseMotionMsg)
 		if isMotionMsg {
 			a.ctx.UIState.Hovered = ""
@@ -126,7 +178,9 @@ func (a *app) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if isMotionMsg {
 				// Longer ID means it is more specific here and thus hovered.
 				// Not sure if that is valid always
-				if a.ctx.UIState.Hovered == "" || len(id) > len(a.ctx.UIState.Hovered) {
+				// A child zone is more specific than the zone of the component itself.
+				if a.ctx.UIState.Hovered == "" || len(id) > len(a.ctx.UIState.Hovered) ||
+					(id == a.ctx.UIState.Hovered && a.ctx.UIState.HoveredChild == "" && childID != "") {
 					a.ctx.UIState.Hovered = id // This will run for each meaning the last one will be the hovered one
 					a.ctx.UIState.HoveredChild = childID
 				}
@@ -208,16 +262,17 @@ func (a *app) View() (string, *tea.Cursor) {
 	rootComponent := a.ctx.RenderWithName(func(c *Ctx, props Props) string {
 		return a.root(c).String()
 	}, nil, "Root")
-	renderedView := a.ctx.zone.Scan(rootComponent.String())
+	renderedView := a.ctx.drawSelection(a.ctx.zone.Scan(a.ctx.drawOverlays(rootComponent.String())))
 
 	// Create or update the timer based on the current set of tick listeners
-	a.ctx.tick.createTimer(a.ctx)
+	a.ctx.tick.start(a.ctx)
+	a.ctx.tick.rendered()
 
 	// Get all component IDs after rendering (new state)
 	currentIDs := a.ctx.ids
 
 	// Determine removed IDs
-	removedIDs := findRemovedIDs(prevIDs, currentIDs)
+	removedIDs := a.ctx.withoutKeptAlive(findRemovedIDs(prevIDs, currentIDs), currentIDs)
 
 	// Cleanup effects for removed components
 	a.ctx.cleanupEffects(removedIDs)
@@ -1,65 +1,112 @@
 package app
 
 import (
-	"sync" // Added for mutex
+	"sync"
 	"time"
-
-	tea "github.com/charmbracelet/bubbletea/v2"
 )
 
 type TickMsg struct {
 	OccurredAt time.Time
 }
 
-type tickState[T any] struct {
-	tickListeners       *[]tickListener
-	timers              *map[time.Duration]*time.Timer
-	lastTickTimes       *map[string]time.Time
-	activeTimer         *time.Timer
-	activeTimerDone     chan struct{}
-	activeTimerInterval time.Duration
-	mu                  sync.Mutex
+// frameMsg asks the update loop to call the tick listeners which are due.
+type frameMsg struct {
+	at time.Time
 }
 
+// defaultMaxFPS is the most frames per second the ticks run at.
+const defaultMaxFPS = 60
+
 type tickListener struct {
 	interval time.Duration
 	id       string
 	callback func()
 }
 
-func (tick *tickState[T]) init() {
-	tick.tickListeners = &[]tickListener{}
+// FrameMetrics tells how long the tick frames take compared to the budget
+// of a frame at the max FPS.
+type FrameMetrics struct {
+	Budget  time.Duration // The time of one frame at the max FPS
+	Last    time.Duration // The time of the last frame, its tick callbacks and render
+	Average time.Duration // The moving average of the frame time
+	Frames  uint64        // How many frames there have been
+	// OverBudget is how many frames took longer than the budget.
+	OverBudget uint64
+}
+
+// scheduler calls the tick listeners in frames. The timer only sends a frame
+// to the update loop when a listener is due and the last frame is handled,
+// so all the callbacks of a frame end in a single render.
+type scheduler struct {
+	mu        sync.Mutex
+	listeners []tickListener
+	lastTicks map[string]time.Time
+	maxFPS    int
+	paused    bool
+	// pending is true from when a frame is sent until it is handled.
+	pending bool
+
+	interval time.Duration
+	done     chan struct{}
+
+	frameStart time.Time
+	metrics    FrameMetrics
 }
 
-// Tell BubbleApp that the component with this ID wants to receive tick events at the given interval.
-// No matter the internal tick interval, the component will receive tick events at the given interval.
-//
-// IMPORTANT: Use intervals with a large common divisor.
-// Ticks will happen internally at the greatest common divisor of all intervals.
-// This means that if you register a tick listener with 1 second and another with 2 seconds,
-// the internal tick will be happen every 1 second.
-// But if you register a tick listener with 80ms and another with 100ms,
-// the internal tick will be happen every 20ms, which might hurt performance.
-func (tick *tickState[T]) RegisterTickListener(interval time.Duration, id string, callback func()) {
-	tick.mu.Lock()
-	defer tick.mu.Unlock()
-	if tick.tickListeners == nil {
-		tick.tickListeners = &[]tickListener{}
+func newScheduler() *scheduler {
+	return &scheduler{
+		lastTicks: make(map[string]time.Time),
+		maxFPS:    defaultMaxFPS,
 	}
-	if tick.timers == nil {
-		tick.timers = &map[time.Duration]*time.Timer{}
+}
+
+// init removes the listeners before a render registers them again. The last
+// ticks of listeners which were not registered by the last render are
+// dropped, like those of removed components and finished animations.
+func (s *scheduler) init() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	registered := make(map[string]bool, len(s.listeners))
+	for _, listener := range s.listeners {
+		registered[listener.id] = true
 	}
-	if tick.lastTickTimes == nil {
-		tick.lastTickTimes = &map[string]time.Time{}
+	for id := range s.lastTicks {
+		if !registered[id] {
+			delete(s.lastTicks, id)
+		}
 	}
+	s.listeners = nil
+}
 
-	*tick.tickListeners = append(*tick.tickListeners, tickListener{
+// register makes the callback be called every interval. The interval is
+// rounded to the frames so use intervals with a large common divisor, like
+// 100ms and 200ms. Intervals like 80ms and 100ms make frames every 20ms.
+func (s *scheduler) register(interval time.Duration, id string, callback func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, tickListener{
 		interval: interval,
 		id:       id,
-		callback: callback, // Store the callback
+		callback: callback,
 	})
 }
 
+func (s *scheduler) unregister(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.listeners) - 1; i >= 0; i-- {
+		if s.listeners[i].id == id {
+			s.listeners = append(s.listeners[:i], s.listeners[i+1:]...)
+		}
+	}
+	delete(s.lastTicks, id)
+}
+
+// budget is the time of a frame at the max FPS.
+func (s *scheduler) budget() time.Duration {
+	return time.Second / time.Duration(max(1, s.maxFPS))
+}
+
 func gcd(a, b time.Duration) time.Duration {
 	for b != 0 {
 		a, b = b, a%b
@@ -67,180 +114,151 @@ func gcd(a, b time.Duration) time.Duration {
 	return a
 }
 
-func gcdSlice(durations []time.Duration) time.Duration {
-	if len(durations) == 0 {
-		return 0
-	}
-	result := durations[0]
-	for _, d := range durations[1:] {
-		result = gcd(result, d)
+// frameInterval is the time between frames. It is the common divisor of the
+// intervals but never shorter than the budget.
+func (s *scheduler) frameInterval() time.Duration {
+	interval := time.Duration(0)
+	for _, listener := range s.listeners {
+		interval = gcd(interval, listener.interval.Truncate(time.Millisecond))
 	}
-	return result
+	return max(s.budget(), interval)
 }
 
-func (tick *tickState[T]) createTimer(ctx *Ctx) {
-	tick.mu.Lock()
-	defer tick.mu.Unlock()
+// start runs the timer for the registered listeners. It is stopped when
+// there are none or the ticks are paused.
+func (s *scheduler) start(c *Ctx) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	if tick.tickListeners == nil || len(*tick.tickListeners) == 0 {
-		if tick.activeTimer != nil {
-			tick.activeTimer.Stop()
-			tick.activeTimer = nil
-		}
-		if tick.activeTimerDone != nil {
-			select {
-			case <-tick.activeTimerDone:
-			default:
-				close(tick.activeTimerDone)
-			}
-			tick.activeTimerDone = nil
-		}
-		tick.activeTimerInterval = 0
-		if tick.timers != nil {
-			*tick.timers = make(map[time.Duration]*time.Timer)
-		}
-		return
-	}
-
-	intervals := make([]time.Duration, 0, len(*tick.tickListeners))
-	for _, listener := range *tick.tickListeners {
-		ms := listener.interval.Milliseconds()
-		intervals = append(intervals, time.Duration(ms)*time.Millisecond)
-	}
-
-	gcdInterval := max(12*time.Millisecond, gcdSlice(intervals)) // 12ms low limit. Maybe too low.
-
-	if gcdInterval == 0 {
-		if tick.activeTimer != nil {
-			tick.activeTimer.Stop()
-			tick.activeTimer = nil
-		}
-		if tick.activeTimerDone != nil {
-			select {
-			case <-tick.activeTimerDone:
-			default:
-				close(tick.activeTimerDone)
-			}
-			tick.activeTimerDone = nil
-		}
-		tick.activeTimerInterval = 0
-		if tick.timers != nil {
-			*tick.timers = make(map[time.Duration]*time.Timer)
-		}
+	if len(s.listeners) == 0 || s.paused || c.teaProgram == nil {
+		s.stopLocked()
 		return
 	}
-
-	if tick.activeTimer != nil && tick.activeTimerInterval == gcdInterval && tick.activeTimerDone != nil {
+	interval := s.frameInterval()
+	if s.done != nil && s.interval == interval {
 		return
 	}
+	s.stopLocked()
 
-	if tick.activeTimer != nil {
-		tick.activeTimer.Stop()
-	}
-	if tick.activeTimerDone != nil {
-		select {
-		case <-tick.activeTimerDone:
-		default:
-			close(tick.activeTimerDone) // Signal old goroutine to stop
-		}
-	}
-
-	tick.activeTimerDone = make(chan struct{})
-	newTimer := time.NewTimer(gcdInterval - 5*time.Millisecond) // 5ms margin to avoid timer drift
-	tick.activeTimer = newTimer
-	tick.activeTimerInterval = gcdInterval
-
-	newTimersMap := make(map[time.Duration]*time.Timer)
-	newTimersMap[gcdInterval] = newTimer
-	if tick.timers == nil {
-		tick.timers = &newTimersMap
-	} else {
-		*tick.timers = newTimersMap
-	}
-
-	go func(timer *time.Timer, done <-chan struct{}, program *tea.Program, interval time.Duration) {
-		defer timer.Stop()
+	done := make(chan struct{})
+	s.done, s.interval = done, interval
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
 		for {
 			select {
-			case <-timer.C:
-				tick.mu.Lock()
-				listeners := *tick.tickListeners
-				now := time.Now()
-				for _, listener := range listeners {
-					lastTick, ok := (*tick.lastTickTimes)[listener.id]
-					if !ok || now.Sub(lastTick) >= listener.interval { // Allow a small margin for timing inaccuracies
-						if listener.callback != nil {
-							go listener.callback()
-						}
-						(*tick.lastTickTimes)[listener.id] = now
-					}
-				}
-				tick.mu.Unlock()
-				select {
-				case <-done:
-					return
-				default:
-					if timer != nil {
-						timer.Reset(interval)
-					}
+			case now := <-ticker.C:
+				if s.due(now) {
+					c.teaProgram.Send(frameMsg{at: now})
 				}
 			case <-done:
 				return
 			}
 		}
-	}(newTimer, tick.activeTimerDone, ctx.teaProgram, gcdInterval)
+	}()
 }
 
-func (tick *tickState[T]) StopActiveTimer() {
-	tick.mu.Lock()
-	defer tick.mu.Unlock()
+// due reports whether a frame should be sent. There is only one frame
+// waiting for the update loop at a time.
+func (s *scheduler) due(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pending || s.paused {
+		return false
+	}
+	for _, listener := range s.listeners {
+		if s.isDue(listener, now) {
+			s.pending = true
+			return true
+		}
+	}
+	return false
+}
+
+// isDue allows half a frame early so a listener fires on the frame closest
+// to its interval.
+func (s *scheduler) isDue(listener tickListener, now time.Time) bool {
+	last, ok := s.lastTicks[listener.id]
+	return !ok || now.Sub(last) >= listener.interval-s.interval/2
+}
 
-	if tick.activeTimerDone != nil {
-		select {
-		case <-tick.activeTimerDone:
-			// Channel already closed
-		default:
-			close(tick.activeTimerDone)
+// frame calls the listeners which are due. It runs on the update loop so the
+// callbacks can change state and their updates end in the same render.
+func (s *scheduler) frame(c *Ctx, at time.Time) {
+	s.mu.Lock()
+	s.pending = false
+	var callbacks []func()
+	for _, listener := range s.listeners {
+		if s.isDue(listener, at) {
+			s.lastTicks[listener.id] = at
+			if listener.callback != nil {
+				callbacks = append(callbacks, listener.callback)
+			}
 		}
-		tick.activeTimerDone = nil
 	}
+	s.mu.Unlock()
 
-	if tick.activeTimer != nil {
-		tick.activeTimer.Stop()
-		tick.activeTimer = nil
+	s.frameStart = time.Now()
+	c.inFrame = true
+	for _, callback := range callbacks {
+		callback()
 	}
+	c.inFrame = false
 }
 
-func (tick *tickState[T]) UnregisterTickListener(id string) {
-	tick.mu.Lock()
-	defer tick.mu.Unlock()
-
-	if tick.tickListeners == nil {
+// rendered measures the frame once it is rendered.
+func (s *scheduler) rendered() {
+	if s.frameStart.IsZero() {
 		return
 	}
+	elapsed := time.Since(s.frameStart)
+	s.frameStart = time.Time{}
 
-	found := false
-	newListeners := []tickListener{}
-	for _, listener := range *tick.tickListeners {
-		if listener.id != id {
-			newListeners = append(newListeners, listener)
-		} else {
-			found = true
-		}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m := &s.metrics
+	m.Frames++
+	m.Last = elapsed
+	if m.Average == 0 {
+		m.Average = elapsed
+	} else {
+		m.Average += (elapsed - m.Average) / 10
+	}
+	if elapsed > s.budget() {
+		m.OverBudget++
 	}
+}
 
-	if found {
-		*tick.tickListeners = newListeners
-		delete(*tick.lastTickTimes, id)
+// pause stops the ticks while the terminal does not have focus.
+func (s *scheduler) pause(paused bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.paused = paused
+	if paused {
+		s.stopLocked()
+	}
+}
 
-		if len(*tick.tickListeners) == 0 {
-			tick.StopActiveTimer()
-		}
+func (s *scheduler) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopLocked()
+}
+
+func (s *scheduler) stopLocked() {
+	if s.done != nil {
+		close(s.done)
+		s.done = nil
 	}
+	s.interval = 0
+	s.pending = false
 }
 
-func tickCommand(duration time.Duration) tea.Cmd {
-	return tea.Tick(duration, func(_ time.Time) tea.Msg {
-		return TickMsg{OccurredAt: time.Now()}
-	})
+// FrameMetrics returns how long the tick frames take.
+func (c *Ctx) FrameMetrics() FrameMetrics {
+	c.tick.mu.Lock()
+	defer c.tick.mu.Unlock()
+	m := c.tick.metrics
+	m.Budget = c.tick.budget()
+	return m
 }
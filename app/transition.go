@@ -0,0 +1,56 @@
+package app
+
+import (
+	"math"
+	"time"
+)
+
+// Easing maps the progress of a transition from 0 to 1 to how far along the
+// animation is.
+type Easing func(t float64) float64
+
+func EaseLinear(t float64) float64 {
+	return t
+}
+func EaseIn(t float64) float64 {
+	return t * t * t
+}
+func EaseOut(t float64) float64 {
+	return 1 - math.Pow(1-t, 3)
+}
+func EaseInOut(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 3)/2
+}
+
+type transitionState struct {
+	key      any
+	start    time.Time
+	progress float64
+}
+
+// UseTransition returns the progress of a transition from 0 to 1. It starts
+// when the component is mounted and again when the key changes, so the key
+// must be comparable. The component is rendered on every tick until the
+// transition is done.
+func UseTransition(c *Ctx, key any, duration time.Duration) float64 {
+	state, _ := UseState(c, &transitionState{key: key, start: time.Now()})
+	if state.key != key {
+		state.key, state.start = key, time.Now()
+	}
+
+	// The progress is taken once per render so all layout phases agree.
+	if c.LayoutPhase == LayoutPhaseIntrincintWidth {
+		state.progress = 1
+		if duration > 0 {
+			state.progress = min(1, float64(time.Since(state.start))/float64(duration))
+		}
+	}
+
+	if state.progress < 1 {
+		animationFrame(c)
+	}
+	return state.progress
+}
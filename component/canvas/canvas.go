@@ -0,0 +1,114 @@
+package canvas
+
+import (
+	"image/color"
+	"strings"
+
+	"github.com/alexanderbh/bubbleapp/app"
+)
+
+type Props struct {
+	Mode Mode
+	// Draw draws on the surface which is cleared and sized to the component
+	// before each render.
+	Draw       func(s *Surface)
+	Color      color.Color
+	Background color.Color
+	app.Layout
+}
+
+type prop func(*Props)
+
+// Canvas is a surface of pixels the size of the component. The surface is
+// kept between renders and only drawn on in the final render.
+func Canvas(c *app.Ctx, rawProps app.Props) string {
+	props, ok := rawProps.(Props)
+	if !ok {
+		panic("Canvas: props must be of type canvas.Props")
+	}
+
+	surface, _ := app.UseState(c, &Surface{})
+
+	width, height := app.UseSize(c)
+	if !props.GrowX && props.Width > 0 {
+		width = props.Width
+	}
+	if !props.GrowY && props.Height > 0 {
+		height = props.Height
+	}
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+
+	// The layout only needs the size so drawing is left for the final render.
+	if c.LayoutPhase != app.LayoutPhaseFinalRender {
+		return strings.TrimSuffix(strings.Repeat(strings.Repeat(" ", width)+"\n", height), "\n")
+	}
+
+	surface.mode = props.Mode
+	surface.Color, surface.Background = props.Color, props.Background
+	surface.Resize(width, height)
+	if props.Draw != nil {
+		props.Draw(surface)
+	}
+	return surface.Render()
+}
+
+// New creates a canvas calling draw to draw on it. It grows to the width
+// and is 10 rows high by default.
+func New(c *app.Ctx, draw func(s *Surface), opts ...prop) *app.C {
+	p := Props{
+		Draw:  draw,
+		Color: c.Theme.ForegroundColor,
+		Layout: app.Layout{
+			GrowX:  true,
+			Height: 10,
+		},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&p)
+		}
+	}
+	return c.Render(Canvas, p)
+}
+
+func WithMode(mode Mode) prop {
+	return func(p *Props) {
+		p.Mode = mode
+	}
+}
+func WithColor(color color.Color) prop {
+	return func(p *Props) {
+		p.Color = color
+	}
+}
+func WithBackground(color color.Color) prop {
+	return func(p *Props) {
+		p.Background = color
+	}
+}
+
+// WithWidth gives the canvas a fixed width in cells instead of growing.
+func WithWidth(width int) prop {
+	return func(p *Props) {
+		p.Width = width
+		p.GrowX = false
+	}
+}
+func WithHeight(height int) prop {
+	return func(p *Props) {
+		p.Height = height
+		p.GrowY = false
+	}
+}
+func WithGrowX(grow bool) prop {
+	return func(p *Props) {
+		p.GrowX = grow
+	}
+}
+func WithGrowY(grow bool) prop {
+	return func(p *Props) {
+		p.GrowY = grow
+	}
+}
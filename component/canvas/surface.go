@@ -0,0 +1,311 @@
+package canvas
+
+import (
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// Mode is how a cell of the terminal is split into pixels.
+type Mode int
+
+const (
+	// Braille splits a cell into 2x4 dots. A cell has a single color which is
+	// the color of the dot drawn last in it.
+	Braille Mode = iota
+	// HalfBlock splits a cell into an upper and a lower pixel which each have
+	// their own color.
+	HalfBlock
+)
+
+// brailleDots are the bits of the dots in a braille cell by column and row.
+var brailleDots = [2][4]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+// Surface is a grid of pixels drawn into cells of the terminal. Pixels drawn
+// with a nil color get the color of the surface.
+type Surface struct {
+	mode       Mode
+	cols, rows int
+	// Color is used for pixels and text drawn without a color.
+	Color color.Color
+	// Background is the background of the cells. Nil leaves it to the
+	// terminal.
+	Background color.Color
+
+	on     []bool
+	colors []color.Color
+	// last is the color drawn last in each cell.
+	last  []color.Color
+	text  []rune
+	textC []color.Color
+}
+
+// NewSurface creates a surface covering cols x rows cells.
+func NewSurface(mode Mode, cols, rows int) *Surface {
+	s := &Surface{mode: mode}
+	s.Resize(cols, rows)
+	return s
+}
+
+// cell returns the size of a cell in pixels.
+func (s *Surface) cell() (int, int) {
+	if s.mode == HalfBlock {
+		return 1, 2
+	}
+	return 2, 4
+}
+
+// Resize sets the number of cells and clears the surface. The buffers are
+// kept when they are large enough.
+func (s *Surface) Resize(cols, rows int) {
+	s.cols, s.rows = max(0, cols), max(0, rows)
+	w, h := s.Size()
+	s.on = resize(s.on, w*h)
+	s.colors = resize(s.colors, w*h)
+	s.last = resize(s.last, s.cols*s.rows)
+	s.text = resize(s.text, s.cols*s.rows)
+	s.textC = resize(s.textC, s.cols*s.rows)
+	s.Clear()
+}
+
+func resize[T any](buf []T, n int) []T {
+	if cap(buf) >= n {
+		return buf[:n]
+	}
+	return make([]T, n)
+}
+
+// Size returns the width and the height in pixels.
+func (s *Surface) Size() (int, int) {
+	cw, ch := s.cell()
+	return s.cols * cw, s.rows * ch
+}
+
+// Clear turns all pixels off and removes the text.
+func (s *Surface) Clear() {
+	clear(s.on)
+	clear(s.colors)
+	clear(s.last)
+	clear(s.text)
+	clear(s.textC)
+}
+
+// Set turns the pixel at x, y on. Pixels outside the surface are ignored.
+func (s *Surface) Set(x, y int, c color.Color) {
+	w, h := s.Size()
+	if x < 0 || y < 0 || x >= w || y >= h {
+		return
+	}
+	cw, ch := s.cell()
+	s.on[y*w+x] = true
+	s.colors[y*w+x] = c
+	s.last[(y/ch)*s.cols+x/cw] = c
+}
+
+// Unset turns the pixel at x, y off.
+func (s *Surface) Unset(x, y int) {
+	w, h := s.Size()
+	if x < 0 || y < 0 || x >= w || y >= h {
+		return
+	}
+	s.on[y*w+x] = false
+}
+
+// IsSet reports whether the pixel at x, y is on.
+func (s *Surface) IsSet(x, y int) bool {
+	w, h := s.Size()
+	return x >= 0 && y >= 0 && x < w && y < h && s.on[y*w+x]
+}
+
+// Fill turns all pixels on.
+func (s *Surface) Fill(c color.Color) {
+	w, h := s.Size()
+	s.FillRect(0, 0, w, h, c)
+}
+
+// Line draws a line between two pixels.
+func (s *Surface) Line(x0, y0, x1, y1 int, c color.Color) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := sign(x1-x0), sign(y1-y0)
+	err := dx + dy
+	for {
+		s.Set(x0, y0, c)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		if e2 := 2 * err; e2 >= dy {
+			err += dy
+			x0 += sx
+		} else {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+// Rect draws the outline of a rectangle.
+func (s *Surface) Rect(x, y, width, height int, c color.Color) {
+	if width <= 0 || height <= 0 {
+		return
+	}
+	right, bottom := x+width-1, y+height-1
+	s.Line(x, y, right, y, c)
+	s.Line(x, bottom, right, bottom, c)
+	s.Line(x, y, x, bottom, c)
+	s.Line(right, y, right, bottom, c)
+}
+
+// FillRect draws a filled rectangle.
+func (s *Surface) FillRect(x, y, width, height int, c color.Color) {
+	for py := y; py < y+height; py++ {
+		for px := x; px < x+width; px++ {
+			s.Set(px, py, c)
+		}
+	}
+}
+
+// Circle draws the outline of a circle around cx, cy.
+func (s *Surface) Circle(cx, cy, radius int, c color.Color) {
+	x, y, err := radius, 0, 1-radius
+	for x >= y {
+		for _, p := range [][2]int{{x, y}, {y, x}, {-y, x}, {-x, y}, {-x, -y}, {-y, -x}, {y, -x}, {x, -y}} {
+			s.Set(cx+p[0], cy+p[1], c)
+		}
+		y++
+		if err < 0 {
+			err += 2*y + 1
+		} else {
+			x--
+			err += 2*(y-x) + 1
+		}
+	}
+}
+
+// FillCircle draws a filled circle around cx, cy.
+func (s *Surface) FillCircle(cx, cy, radius int, c color.Color) {
+	for dy := -radius; dy <= radius; dy++ {
+		dx := int(math.Sqrt(float64(radius*radius - dy*dy)))
+		s.Line(cx-dx, cy+dy, cx+dx, cy+dy, c)
+	}
+}
+
+// Text writes the text from the cell containing the pixel at x, y. Each rune
+// takes a cell and covers the pixels in it.
+func (s *Surface) Text(x, y int, text string, c color.Color) {
+	cw, ch := s.cell()
+	col, row := x/cw, y/ch
+	if x < 0 || y < 0 || row >= s.rows {
+		return
+	}
+	for _, r := range text {
+		if col >= s.cols {
+			return
+		}
+		s.text[row*s.cols+col] = r
+		s.textC[row*s.cols+col] = c
+		col++
+	}
+}
+
+// Lines returns the rows of cells. Cells next to each other with the same
+// colors are styled together.
+func (s *Surface) Lines() []string {
+	w, _ := s.Size()
+	lines := make([]string, s.rows)
+	for row := range s.rows {
+		var b strings.Builder
+		run := []rune{}
+		var runFg, runBg color.Color
+		flush := func() {
+			if len(run) == 0 {
+				return
+			}
+			style := lipgloss.NewStyle()
+			if runFg != nil {
+				style = style.Foreground(runFg)
+			}
+			if runBg != nil {
+				style = style.Background(runBg)
+			}
+			b.WriteString(style.Render(string(run)))
+			run = run[:0]
+		}
+		for col := range s.cols {
+			r, fg, bg := s.at(col, row, w)
+			if len(run) > 0 && (fg != runFg || bg != runBg) {
+				flush()
+			}
+			run, runFg, runBg = append(run, r), fg, bg
+		}
+		flush()
+		lines[row] = b.String()
+	}
+	return lines
+}
+
+// Render returns the surface as a string.
+func (s *Surface) Render() string {
+	return strings.Join(s.Lines(), "\n")
+}
+
+// at returns the rune and the colors of a cell. w is the width in pixels.
+func (s *Surface) at(col, row int, w int) (rune, color.Color, color.Color) {
+	i := row*s.cols + col
+	if s.text[i] != 0 {
+		return s.text[i], s.or(s.textC[i]), s.Background
+	}
+	if s.mode == HalfBlock {
+		top, bottom := row*2*w+col, (row*2+1)*w+col
+		switch {
+		case s.on[top] && s.on[bottom]:
+			if s.or(s.colors[top]) == s.or(s.colors[bottom]) {
+				return '█', s.or(s.colors[top]), s.Background
+			}
+			return '▀', s.or(s.colors[top]), s.or(s.colors[bottom])
+		case s.on[top]:
+			return '▀', s.or(s.colors[top]), s.Background
+		case s.on[bottom]:
+			return '▄', s.or(s.colors[bottom]), s.Background
+		}
+		return ' ', nil, s.Background
+	}
+	dots := rune(0)
+	for dx := range 2 {
+		for dy := range 4 {
+			if s.on[(row*4+dy)*w+col*2+dx] {
+				dots |= brailleDots[dx][dy]
+			}
+		}
+	}
+	if dots == 0 {
+		return ' ', nil, s.Background
+	}
+	return 0x2800 + dots, s.or(s.last[i]), s.Background
+}
+
+// or returns the color or the color of the surface when it is nil.
+func (s *Surface) or(c color.Color) color.Color {
+	if c == nil {
+		return s.Color
+	}
+	return c
+}
+
+func abs(v int) int {
+	return max(v, -v)
+}
+
+func sign(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,328 @@
+package chart
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/style"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// Series is a named list of values drawn in the color of the variant.
+type Series struct {
+	Name    string
+	Values  []float64
+	Variant style.Variant
+}
+
+// Bar is a single bar of a bar chart.
+type Bar struct {
+	Label   string
+	Value   float64
+	Variant style.Variant
+}
+
+type mode int
+
+const (
+	modeSparkline mode = iota
+	modeLine
+	modeBar
+	modeHistogram
+)
+
+type Props struct {
+	mode   mode
+	Series []Series
+	Bars   []Bar
+	// Bins is the number of bars of a histogram.
+	Bins int
+	// Min and Max fix the range of the values. When they are equal the range
+	// is taken from the data. For a histogram they are the range of the bins.
+	Min, Max float64
+	ShowAxes bool
+	Styles   style.ChartTheme
+	app.Layout
+}
+
+type prop func(*Props)
+
+type chartState struct {
+	hovering bool
+	mouse    tea.Position
+}
+
+// plot is a rendered chart without its axes. at returns the tooltip of the
+// cell at x, y of the plot.
+type plot struct {
+	lines  []string
+	footer []string
+	at     func(x, y int) string
+}
+
+// Chart draws values as a sparkline, a line chart, a bar chart or a
+// histogram. Hovering the chart with the mouse shows the values under it.
+func Chart(c *app.Ctx, rawProps app.Props) string {
+	props, ok := rawProps.(Props)
+	if !ok {
+		panic("Chart: props must be of type chart.Props")
+	}
+
+	state, _ := app.UseState(c, &chartState{})
+	hovered, _ := app.UseIsHovered(c)
+	x, y := app.UseGlobalPosition(c)
+
+	app.UseMouseHandler(c, func(msg tea.MouseMsg, childID string) bool {
+		if _, ok := msg.(tea.MouseMotionMsg); ok {
+			mouse := msg.Mouse()
+			state.hovering, state.mouse = true, tea.Position{X: mouse.X, Y: mouse.Y}
+			c.Update()
+		}
+		return false
+	})
+
+	width, height := app.UseSize(c)
+	if !props.GrowX && props.Width > 0 {
+		width = props.Width
+	}
+	if !props.GrowY && props.Height > 0 {
+		height = props.Height
+	}
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+
+	bars := props.Bars
+	if props.mode == modeHistogram {
+		bars = histogram(props)
+	}
+	lo, hi := valueRange(props, bars)
+
+	// The y axis is labeled with the highest and the lowest value and the
+	// one between them when there is room.
+	var axis []string
+	plotWidth, plotHeight, left := width, height, 0
+	if props.ShowAxes && props.mode != modeSparkline {
+		plotHeight--
+		if props.mode != modeLine || hasNames(props.Series) {
+			plotHeight--
+		}
+		axis = []string{formatValue(hi), formatValue((lo + hi) / 2), formatValue(lo)}
+		for _, label := range axis {
+			left = max(left, lipgloss.Width(label))
+		}
+		left++
+		plotWidth -= left
+	}
+	if plotWidth <= 0 || plotHeight <= 0 {
+		return ""
+	}
+
+	var p plot
+	switch props.mode {
+	case modeSparkline:
+		p = sparkline(props, lo, hi, plotWidth, plotHeight)
+	case modeLine:
+		p = lineChart(props, lo, hi, plotWidth, plotHeight)
+	default:
+		p = barChart(props, bars, lo, hi, plotWidth, plotHeight)
+	}
+
+	lines := p.lines
+	if axis != nil {
+		lines = withAxes(props, p, axis, left, plotWidth)
+	}
+
+	if hovered && state.hovering {
+		px, py := state.mouse.X-x-left, state.mouse.Y-y
+		if px >= 0 && px < plotWidth && py >= 0 && py < plotHeight {
+			if tip := p.at(px, py); tip != "" {
+				app.UseOverlay(c, app.Overlay{
+					Content: props.Styles.Tooltip.Render(tip),
+					Anchor:  app.Rect{X: state.mouse.X + 1, Y: state.mouse.Y, Width: 1, Height: 1},
+				})
+			}
+		}
+	}
+
+	// Lines are padded to the width as the mouse zone ends where the last
+	// line ends.
+	for i, line := range lines {
+		lines[i] = line + strings.Repeat(" ", max(0, width-lipgloss.Width(line)))
+	}
+	return c.MouseZone(strings.Join(lines, "\n"))
+}
+
+// withAxes puts the y axis with its labels left of the plot and the x axis
+// below it.
+func withAxes(props Props, p plot, axis []string, left int, plotWidth int) []string {
+	height := len(p.lines)
+	labels := map[int]string{0: axis[0], height - 1: axis[2]}
+	if height >= 5 {
+		labels[(height-1)/2] = axis[1]
+	}
+	lines := make([]string, 0, height+1+len(p.footer))
+	for row, line := range p.lines {
+		label, tick := "", "│"
+		if l, ok := labels[row]; ok {
+			label, tick = l, "┤"
+		}
+		label = strings.Repeat(" ", left-1-lipgloss.Width(label)) + label
+		lines = append(lines, props.Styles.Label.Render(label)+props.Styles.Axis.Render(tick)+line)
+	}
+	lines = append(lines, strings.Repeat(" ", left-1)+props.Styles.Axis.Render("└"+strings.Repeat("─", plotWidth)))
+	for _, footer := range p.footer {
+		lines = append(lines, strings.Repeat(" ", left)+footer)
+	}
+	return lines
+}
+
+// valueRange returns the range of the values shown. Bars always start from
+// zero.
+func valueRange(props Props, bars []Bar) (float64, float64) {
+	if props.Min != props.Max && props.mode != modeHistogram {
+		return props.Min, props.Max
+	}
+	values := []float64{}
+	for _, s := range props.Series {
+		values = append(values, s.Values...)
+	}
+	if props.mode == modeBar || props.mode == modeHistogram {
+		values = []float64{0}
+		for _, b := range bars {
+			values = append(values, b.Value)
+		}
+	}
+	if len(values) == 0 {
+		return 0, 1
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	if lo == hi {
+		hi = lo + 1
+	}
+	return lo, hi
+}
+
+func hasNames(series []Series) bool {
+	for _, s := range series {
+		if s.Name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func formatValue(v float64) string {
+	if v == float64(int64(v)) || v >= 1000 || v <= -1000 {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.3g", v)
+}
+
+func newProps(c *app.Ctx, m mode, series []Series, bars []Bar, opts []prop) Props {
+	p := Props{
+		mode:     m,
+		Series:   series,
+		Bars:     bars,
+		Bins:     10,
+		ShowAxes: true,
+		Styles:   c.Theme.Chart,
+		Layout: app.Layout{
+			GrowX:  true,
+			Height: 10,
+		},
+	}
+	if m == modeSparkline {
+		p.Height = 1
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&p)
+		}
+	}
+	return p
+}
+
+// NewSparkline creates a small chart of the values without axes. The latest
+// values that fit in the width are shown.
+func NewSparkline(c *app.Ctx, values []float64, opts ...prop) *app.C {
+	return c.Render(Chart, newProps(c, modeSparkline, []Series{{Values: values}}, nil, opts))
+}
+
+// NewLine creates a line chart drawn with braille dots. When a series has
+// more values than fit in the width the latest values are shown.
+func NewLine(c *app.Ctx, series []Series, opts ...prop) *app.C {
+	return c.Render(Chart, newProps(c, modeLine, series, nil, opts))
+}
+
+// NewBar creates a bar chart with the labels below the bars.
+func NewBar(c *app.Ctx, bars []Bar, opts ...prop) *app.C {
+	return c.Render(Chart, newProps(c, modeBar, nil, bars, opts))
+}
+
+// NewHistogram creates a bar chart counting how many of the values fall in
+// each of the bins between the lowest and the highest value.
+func NewHistogram(c *app.Ctx, values []float64, opts ...prop) *app.C {
+	return c.Render(Chart, newProps(c, modeHistogram, []Series{{Values: values}}, nil, opts))
+}
+
+// WithVariant sets the color of a sparkline or a histogram.
+func WithVariant(variant style.Variant) prop {
+	return func(p *Props) {
+		if p.mode == modeSparkline || p.mode == modeHistogram {
+			p.Series[0].Variant = variant
+		}
+	}
+}
+
+// WithRange fixes the range of the values instead of taking it from the
+// data.
+func WithRange(min, max float64) prop {
+	return func(p *Props) {
+		p.Min, p.Max = min, max
+	}
+}
+func WithBins(bins int) prop {
+	return func(p *Props) {
+		p.Bins = max(1, bins)
+	}
+}
+func WithShowAxes(show bool) prop {
+	return func(p *Props) {
+		p.ShowAxes = show
+	}
+}
+func WithStyles(styles style.ChartTheme) prop {
+	return func(p *Props) {
+		p.Styles = styles
+	}
+}
+
+// WithWidth gives the chart a fixed width instead of growing.
+func WithWidth(width int) prop {
+	return func(p *Props) {
+		p.Width = width
+		p.GrowX = false
+	}
+}
+func WithHeight(height int) prop {
+	return func(p *Props) {
+		p.Height = height
+		p.GrowY = false
+	}
+}
+func WithGrowX(grow bool) prop {
+	return func(p *Props) {
+		p.GrowX = grow
+	}
+}
+func WithGrowY(grow bool) prop {
+	return func(p *Props) {
+		p.GrowY = grow
+	}
+}
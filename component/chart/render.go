@@ -0,0 +1,227 @@
+package chart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/alexanderbh/bubbleapp/component/canvas"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// blocks are the blocks filling one to eight eighths of a cell from the
+// bottom.
+var blocks = []rune{' ', '▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+
+// column returns the rows of a column filled to eighths from the bottom.
+func column(eighths int, height int) []rune {
+	cells := make([]rune, height)
+	for row := range height {
+		fill := eighths - (height-1-row)*8
+		cells[row] = blocks[max(0, min(fill, 8))]
+	}
+	return cells
+}
+
+// latest returns the values that fit in n.
+func latest(values []float64, n int) []float64 {
+	return values[max(0, len(values)-n):]
+}
+
+func scale(v, lo, hi float64) float64 {
+	return max(0, min((v-lo)/(hi-lo), 1))
+}
+
+func sparkline(props Props, lo, hi float64, width int, height int) plot {
+	values := latest(props.Series[0].Values, width)
+	style := props.Styles.Series[props.Series[0].Variant]
+	grid := make([][]rune, height)
+	for row := range grid {
+		grid[row] = []rune(strings.Repeat(" ", width))
+	}
+	for x, v := range values {
+		// The lowest value still gets the smallest block so the line is
+		// never broken.
+		eighths := 1 + int(math.Round(scale(v, lo, hi)*float64(height*8-1)))
+		for row, cell := range column(eighths, height) {
+			grid[row][x] = cell
+		}
+	}
+	lines := make([]string, height)
+	for row := range grid {
+		lines[row] = style.Render(string(grid[row]))
+	}
+	return plot{
+		lines: lines,
+		at: func(x, _ int) string {
+			if x >= len(values) {
+				return ""
+			}
+			return formatValue(values[x])
+		},
+	}
+}
+
+func lineChart(props Props, lo, hi float64, width int, height int) plot {
+	surface := canvas.NewSurface(canvas.Braille, width, height)
+	dotWidth, dotHeight := surface.Size()
+
+	// xOf returns the dot column of the value at i of n values. Fewer values
+	// than columns are spread over the width.
+	xOf := func(i, n int) int {
+		if n <= 1 {
+			return 0
+		}
+		return int(math.Round(float64(i) * float64(dotWidth-1) / float64(n-1)))
+	}
+	shown := make([][]float64, len(props.Series))
+	for s, series := range props.Series {
+		values := latest(series.Values, dotWidth)
+		shown[s] = values
+		color := props.Styles.Series[series.Variant].GetForeground()
+		px, py := 0, 0
+		for i, v := range values {
+			x := xOf(i, len(values))
+			y := int(math.Round((1 - scale(v, lo, hi)) * float64(dotHeight-1)))
+			if i == 0 {
+				px, py = x, y
+			}
+			surface.Line(px, py, x, y, color)
+			px, py = x, y
+		}
+	}
+
+	lines := surface.Lines()
+
+	var footer []string
+	if hasNames(props.Series) {
+		legend := []string{}
+		for _, s := range props.Series {
+			legend = append(legend, props.Styles.Series[s.Variant].Render("●")+" "+props.Styles.Label.Render(s.Name))
+		}
+		footer = []string{ansi.Truncate(strings.Join(legend, "  "), width, "…")}
+	}
+
+	return plot{
+		lines:  lines,
+		footer: footer,
+		at: func(x, _ int) string {
+			tips := []string{}
+			for s, values := range shown {
+				if len(values) == 0 {
+					continue
+				}
+				// The value closest to the middle of the cell.
+				i := 0
+				if len(values) > 1 {
+					i = int(math.Round((float64(x*2) + 0.5) * float64(len(values)-1) / float64(dotWidth-1)))
+				}
+				i = max(0, min(i, len(values)-1))
+				tip := formatValue(values[i])
+				if name := props.Series[s].Name; name != "" {
+					tip = props.Styles.Series[props.Series[s].Variant].Render("●") + " " + name + ": " + tip
+				}
+				tips = append(tips, tip)
+			}
+			return strings.Join(tips, "\n")
+		},
+	}
+}
+
+func barChart(props Props, bars []Bar, lo, hi float64, width int, height int) plot {
+	// Bars are as wide as they can be with a gap between them. Bars that do
+	// not fit are left out from the start.
+	barWidth := 1
+	if len(bars) > 0 {
+		barWidth = max(1, (width+1)/len(bars)-1)
+	}
+	bars = bars[max(0, len(bars)-(width+1)/(barWidth+1)):]
+
+	grid := make([]strings.Builder, height)
+	labels := strings.Builder{}
+	for i, bar := range bars {
+		gap := ""
+		if i > 0 {
+			gap = " "
+		}
+		style := props.Styles.Series[bar.Variant]
+		cells := column(int(math.Round(scale(bar.Value, lo, hi)*float64(height*8))), height)
+		for row := range height {
+			grid[row].WriteString(gap + style.Render(strings.Repeat(string(cells[row]), barWidth)))
+		}
+		label := ansi.Truncate(bar.Label, barWidth, "")
+		pad := barWidth - lipgloss.Width(label)
+		labels.WriteString(gap + strings.Repeat(" ", pad/2) + label + strings.Repeat(" ", pad-pad/2))
+	}
+	lines := make([]string, height)
+	for row := range grid {
+		lines[row] = grid[row].String()
+	}
+
+	var footer []string
+	if props.mode == modeHistogram {
+		from, to := histogramRange(props)
+		start, end := formatValue(from), formatValue(to)
+		pad := max(1, min(width, len(bars)*(barWidth+1)-1)-lipgloss.Width(start)-lipgloss.Width(end))
+		footer = []string{props.Styles.Label.Render(start + strings.Repeat(" ", pad) + end)}
+	} else {
+		footer = []string{props.Styles.Label.Render(labels.String())}
+	}
+
+	return plot{
+		lines:  lines,
+		footer: footer,
+		at: func(x, _ int) string {
+			i := x / (barWidth + 1)
+			if x%(barWidth+1) == barWidth || i >= len(bars) {
+				return ""
+			}
+			if bars[i].Label == "" {
+				return formatValue(bars[i].Value)
+			}
+			return bars[i].Label + ": " + formatValue(bars[i].Value)
+		},
+	}
+}
+
+// histogramRange returns the range the bins of a histogram cover.
+func histogramRange(props Props) (float64, float64) {
+	if props.Min != props.Max {
+		return props.Min, props.Max
+	}
+	values := props.Series[0].Values
+	if len(values) == 0 {
+		return 0, 1
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	if lo == hi {
+		hi = lo + 1
+	}
+	return lo, hi
+}
+
+// histogram counts the values in each bin. The labels are the ranges of the
+// bins so they show in the tooltip.
+func histogram(props Props) []Bar {
+	lo, hi := histogramRange(props)
+	bins := max(1, props.Bins)
+	size := (hi - lo) / float64(bins)
+	bars := make([]Bar, bins)
+	for i := range bars {
+		bars[i] = Bar{
+			Label:   fmt.Sprintf("%s–%s", formatValue(lo+float64(i)*size), formatValue(lo+float64(i+1)*size)),
+			Variant: props.Series[0].Variant,
+		}
+	}
+	for _, v := range props.Series[0].Values {
+		if v < lo || v > hi {
+			continue
+		}
+		bars[min(int((v-lo)/size), bins-1)].Value++
+	}
+	return bars
+}
@@ -0,0 +1,45 @@
+package chart
+
+import (
+	"sync"
+
+	"github.com/alexanderbh/bubbleapp/app"
+)
+
+// Stream keeps the latest values of a series as they arrive. It is safe to
+// push values from other goroutines.
+type Stream struct {
+	mu     sync.Mutex
+	values []float64
+	size   int
+	c      *app.Ctx
+}
+
+// UseStream returns a stream keeping the last size values. Pushing values
+// renders the component again.
+func UseStream(c *app.Ctx, size int) *Stream {
+	stream, _ := app.UseState(c, &Stream{size: size})
+	stream.mu.Lock()
+	stream.c, stream.size = c, size
+	stream.mu.Unlock()
+	return stream
+}
+
+// Push adds the values dropping the oldest when there are more than the
+// size of the stream.
+func (s *Stream) Push(values ...float64) {
+	s.mu.Lock()
+	s.values = append(s.values, values...)
+	if len(s.values) > s.size {
+		s.values = append([]float64(nil), s.values[len(s.values)-s.size:]...)
+	}
+	s.mu.Unlock()
+	s.c.Update()
+}
+
+// Values returns a copy of the values in the stream from the oldest.
+func (s *Stream) Values() []float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]float64(nil), s.values...)
+}
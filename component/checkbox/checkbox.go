@@ -0,0 +1,99 @@
+package checkbox
+
+import (
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/style"
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+type Props struct {
+	Label    string
+	Checked  bool
+	Disabled bool
+	Variant  style.Variant
+	OnChange func(checked bool)
+	app.Layout
+}
+
+type prop func(*Props)
+
+// Checkbox is a box that is checked and unchecked with space, enter or a
+// click. The label is shown next to it.
+func Checkbox(c *app.Ctx, rawProps app.Props) string {
+	props, ok := rawProps.(Props)
+	if !ok {
+		panic("Checkbox: props must be of type checkbox.Props")
+	}
+
+	id := app.UseID(c)
+	// A disabled control can not be focused.
+	focused := !props.Disabled && app.UseIsFocused(c)
+	hovered, _ := app.UseIsHovered(c)
+
+	toggle := func() {
+		c.FocusThis(id)
+		if props.OnChange != nil {
+			props.OnChange(!props.Checked)
+		}
+	}
+
+	if !props.Disabled {
+		app.UseAction(c, func(_ string) {
+			toggle()
+		})
+		app.UseKeyHandler(c, func(keyMsg tea.KeyMsg) bool {
+			if keyMsg.String() != "space" {
+				return false
+			}
+			toggle()
+			return true
+		})
+	}
+
+	state := style.Normal
+	if props.Disabled {
+		state = style.Disabled
+	} else if hovered {
+		state = style.Hover
+	} else if focused {
+		state = style.Focus
+	}
+
+	mark := "[ ]"
+	if props.Checked {
+		mark = "[x]"
+	}
+	content := c.Theme.Checkbox[props.Variant][state].Render(mark)
+	if props.Label != "" {
+		content += " " + c.Theme.Text[style.Base][state].Render(props.Label)
+	}
+	return c.MouseZone(content)
+}
+
+// New creates a checkbox. onChange is called with the new checked state.
+func New(c *app.Ctx, label string, checked bool, onChange func(checked bool), opts ...prop) *app.C {
+	p := Props{
+		Label:    label,
+		Checked:  checked,
+		OnChange: onChange,
+		Variant:  style.Primary,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&p)
+		}
+	}
+	return c.Render(Checkbox, p)
+}
+
+func WithVariant(variant style.Variant) prop {
+	return func(p *Props) {
+		p.Variant = variant
+	}
+}
+
+func WithDisabled(disabled bool) prop {
+	return func(p *Props) {
+		p.Disabled = disabled
+	}
+}
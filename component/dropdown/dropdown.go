@@ -0,0 +1,722 @@
+package dropdown
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/component/internal/textinput"
+	"github.com/alexanderbh/bubbleapp/style"
+	"github.com/charmbracelet/bubbles/v2/key"
+	bubblestextinput "github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mattn/go-runewidth"
+)
+
+// Option is a single choice in a dropdown.
+type Option struct {
+	Label string
+	// Value is not used by the dropdown. It is there to keep the data of the
+	// option next to its label.
+	Value    any
+	Disabled bool
+}
+
+// Options creates enabled options from the labels.
+func Options(labels ...string) []Option {
+	options := make([]Option, len(labels))
+	for i, label := range labels {
+		options[i] = Option{Label: label, Value: label}
+	}
+	return options
+}
+
+type mode int
+
+const (
+	modeSelect mode = iota
+	modeCombobox
+	modeMulti
+)
+
+// Props holds the configuration for the Dropdown component. Use New,
+// NewCombobox or NewMulti to create one of the three kinds.
+type Props struct {
+	Key     string
+	Options []Option
+	// Selected is the index of the chosen option of a select or -1.
+	Selected int
+	// SelectedSet is the indexes of the chosen options of a multiselect.
+	SelectedSet []int
+	// Value is the text of a combobox.
+	Value       string
+	Placeholder string
+	Disabled    bool
+	// MaxHeight is the most options shown in the menu at once.
+	MaxHeight int
+	// OnChange is called with the index of the chosen option. A combobox
+	// calls it when an option is picked from the menu.
+	OnChange          func(index int)
+	OnSelectionChange func(selected []int)
+	OnTextChange      func(text string)
+	KeyMap            KeyMap
+	Styles            style.DropdownTheme
+	app.Layout
+
+	mode mode
+}
+
+type prop func(*Props)
+
+type KeyMap struct {
+	Open   key.Binding
+	Close  key.Binding
+	Up     key.Binding
+	Down   key.Binding
+	Choose key.Binding
+	// Toggle chooses the option with the cursor without closing a multiselect.
+	Toggle key.Binding
+	// Remove removes the last chosen option of a closed multiselect.
+	Remove key.Binding
+}
+
+func (km KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{km.Open, km.Up, km.Down, km.Choose}
+}
+
+func (km KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{km.Open, km.Close, km.Up, km.Down},
+		{km.Choose, km.Toggle, km.Remove},
+	}
+}
+
+func defaultKeyMap() KeyMap {
+	return KeyMap{
+		Open: key.NewBinding(
+			key.WithKeys("enter", "space", "down"),
+			key.WithHelp("enter", "open"),
+		),
+		Close: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "close"),
+		),
+		Up: key.NewBinding(
+			key.WithKeys("up"),
+			key.WithHelp("↑", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down"),
+			key.WithHelp("↓", "down"),
+		),
+		Choose: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "choose"),
+		),
+		Toggle: key.NewBinding(
+			key.WithKeys("space"),
+			key.WithHelp("space", "toggle"),
+		),
+		Remove: key.NewBinding(
+			key.WithKeys("backspace"),
+			key.WithHelp("backspace", "remove last"),
+		),
+	}
+}
+
+// typeAheadTimeout is how long typed characters are combined into one search.
+const typeAheadTimeout = time.Second
+
+// dropdownState is mutated in place by the event handlers which call c.Update().
+type dropdownState struct {
+	open   bool
+	cursor int // Position in the shown options
+	top    int
+	follow bool
+
+	// input is the text input of a combobox.
+	input *textinput.Model
+
+	typeAhead   string
+	typeAheadAt time.Time
+}
+
+// Dropdown is the functional component for the select, combobox and
+// multiselect. The options are shown in an overlay below the field.
+func Dropdown(c *app.Ctx, rawProps app.Props) string {
+	props, ok := rawProps.(Props)
+	if !ok {
+		panic("Dropdown: props must be of type dropdown.Props")
+	}
+
+	id := app.UseID(c)
+	isFocused := app.UseIsFocused(c)
+	isHovered, childHoverID := app.UseIsHovered(c)
+	state, _ := app.UseState(c, &dropdownState{})
+	width, _ := app.UseSize(c)
+
+	if props.mode == modeCombobox && state.input == nil {
+		input := textinput.New()
+		input.Prompt = ""
+		input.ShowSuggestions = true
+		input.SetValue(props.Value)
+		state.input = &input
+	}
+
+	if props.mode == modeCombobox {
+		app.UseEffect(c, func() {
+			if isFocused {
+				state.input.Focus()
+			} else {
+				state.input.Blur()
+			}
+		}, []any{isFocused})
+
+		app.UseEffect(c, func() {
+			if state.input.Value() != props.Value {
+				state.input.SetValue(props.Value)
+			}
+		}, []any{props.Value})
+
+		// Disabled options are never suggested.
+		var suggestions []string
+		for _, option := range props.Options {
+			if !option.Disabled {
+				suggestions = append(suggestions, option.Label)
+			}
+		}
+		app.UseEffect(c, func() {
+			state.input.SetSuggestions(suggestions)
+		}, []any{strings.Join(suggestions, "\n")})
+	}
+
+	shown, cursor := shownOptions(props, state)
+	open := state.open && isFocused && !props.Disabled && len(shown) > 0
+	maxHeight := max(props.MaxHeight, 1)
+
+	isSelected := func(index int) bool {
+		if props.mode == modeMulti {
+			return slices.Contains(props.SelectedSet, index)
+		}
+		return props.mode == modeSelect && index == props.Selected
+	}
+
+	// firstCursor is where the cursor starts when the menu is opened.
+	firstCursor := func() int {
+		if props.mode == modeSelect {
+			if pos := slices.Index(shown, props.Selected); pos >= 0 {
+				return pos
+			}
+		}
+		return nextEnabled(props.Options, shown, -1, 1)
+	}
+
+	setOpen := func(value bool) {
+		if state.open == value {
+			return
+		}
+		state.open = value
+		state.follow = true
+		if value {
+			state.cursor = firstCursor()
+		}
+		c.Update()
+	}
+
+	setCursor := func(pos int) {
+		state.cursor = pos
+		state.follow = true
+		c.Update()
+	}
+
+	toggle := func(index int) {
+		selected := slices.Clone(props.SelectedSet)
+		if i := slices.Index(selected, index); i >= 0 {
+			selected = slices.Delete(selected, i, i+1)
+		} else {
+			selected = append(selected, index)
+			slices.Sort(selected)
+		}
+		c.Update()
+		if props.OnSelectionChange != nil {
+			props.OnSelectionChange(selected)
+		}
+	}
+
+	choose := func(pos int) {
+		if pos < 0 || pos >= len(shown) || props.Options[shown[pos]].Disabled {
+			return
+		}
+		index := shown[pos]
+		switch props.mode {
+		case modeMulti:
+			setCursor(pos)
+			toggle(index)
+			return
+		case modeCombobox:
+			label := props.Options[index].Label
+			state.input.SetValue(label)
+			state.input.CursorEnd()
+			if props.OnTextChange != nil && label != props.Value {
+				props.OnTextChange(label)
+			}
+		}
+		state.open = false
+		c.Update()
+		if props.OnChange != nil {
+			props.OnChange(index)
+		}
+	}
+
+	app.UseKeyHandler(c, func(keyMsg tea.KeyMsg) bool {
+		if props.Disabled {
+			return false
+		}
+		if props.mode == modeCombobox {
+			return comboboxKey(c, props, state, keyMsg, open, len(shown) > 0, cursor, setOpen, setCursor, choose)
+		}
+
+		if !open {
+			switch {
+			case key.Matches(keyMsg, props.KeyMap.Open):
+				setOpen(true)
+			case props.mode == modeMulti && key.Matches(keyMsg, props.KeyMap.Remove):
+				if len(props.SelectedSet) == 0 {
+					return false
+				}
+				toggle(props.SelectedSet[len(props.SelectedSet)-1])
+			case props.mode == modeSelect && keyMsg.Key().Text != "" && keyMsg.Key().Text != " ":
+				// Typing on a closed select chooses like a native select.
+				typeAhead(state, props.Options, shown, slices.Index(shown, props.Selected), keyMsg.Key().Text, choose)
+			default:
+				return false
+			}
+			return true
+		}
+
+		switch {
+		case key.Matches(keyMsg, props.KeyMap.Close):
+			setOpen(false)
+		case key.Matches(keyMsg, props.KeyMap.Up):
+			setCursor(nextEnabled(props.Options, shown, cursor, -1))
+		case key.Matches(keyMsg, props.KeyMap.Down):
+			setCursor(nextEnabled(props.Options, shown, cursor, 1))
+		case key.Matches(keyMsg, props.KeyMap.Choose), key.Matches(keyMsg, props.KeyMap.Toggle):
+			choose(cursor)
+		default:
+			text := keyMsg.Key().Text
+			if text == "" {
+				// Let keys like tab move the focus away and close the menu.
+				state.open = false
+				c.Update()
+				return false
+			}
+			typeAhead(state, props.Options, shown, cursor, text, setCursor)
+		}
+		return true
+	})
+
+	app.UseMouseHandler(c, func(msg tea.MouseMsg, childID string) bool {
+		if props.Disabled {
+			return false
+		}
+		switch msg := msg.(type) {
+		case tea.MouseWheelMsg:
+			if !open || !strings.HasPrefix(childID, "option:") {
+				return false
+			}
+			switch msg.Button {
+			case tea.MouseWheelDown:
+				state.top = min(state.top+1, max(len(shown)-maxHeight, 0))
+			case tea.MouseWheelUp:
+				state.top = max(state.top-1, 0)
+			default:
+				return false
+			}
+			state.follow = false
+			c.Update()
+			return true
+		case tea.MouseReleaseMsg:
+			if msg.Button != tea.MouseLeft {
+				return false
+			}
+			c.FocusThis(id)
+			kind, rawIndex, _ := strings.Cut(childID, ":")
+			index, err := strconv.Atoi(rawIndex)
+			switch {
+			case kind == "option" && err == nil:
+				choose(index)
+			case kind == "chip" && err == nil:
+				toggle(index)
+			default:
+				setOpen(!open)
+			}
+			return true
+		}
+		return false
+	})
+
+	if props.mode == modeCombobox && isFocused {
+		app.UseCursor(c, state.input.Cursor(), 1, 0)
+	}
+
+	// Keep the cursor inside the menu. The state is only touched in the last
+	// layout phase where the sizes are final.
+	top := clamp(state.top, 0, max(len(shown)-maxHeight, 0))
+	if state.follow && cursor >= 0 {
+		if cursor < top {
+			top = cursor
+		} else if cursor >= top+maxHeight {
+			top = cursor - maxHeight + 1
+		}
+	}
+	if c.LayoutPhase == app.LayoutPhaseFinalRender {
+		state.open, state.top, state.follow = open, top, false
+	}
+
+	fieldStyle := props.Styles.Field[style.Normal]
+	switch {
+	case props.Disabled:
+		fieldStyle = props.Styles.Field[style.Disabled]
+	case isFocused:
+		fieldStyle = props.Styles.Field[style.Focus]
+	case isHovered:
+		fieldStyle = props.Styles.Field[style.Hover]
+	}
+
+	fieldWidth := naturalWidth(props)
+	if props.Layout.Width > 0 {
+		fieldWidth = props.Layout.Width
+	} else if props.Layout.GrowX {
+		fieldWidth = width
+	}
+	fieldWidth = max(min(fieldWidth, width), 4)
+
+	field := renderField(c, props, state, fieldStyle, fieldWidth, open)
+
+	if open {
+		menuWidth := max(fieldWidth-props.Styles.Menu.GetHorizontalFrameSize(), 1)
+		menuBg := lipgloss.NewStyle().Background(props.Styles.Menu.GetBackground())
+		lines := make([]string, 0, maxHeight)
+		for pos := top; pos < len(shown) && pos < top+maxHeight; pos++ {
+			index := shown[pos]
+			option := props.Options[index]
+			optionID := "option:" + strconv.Itoa(pos)
+
+			s := props.Styles.Option
+			switch {
+			case option.Disabled:
+				s = props.Styles.OptionDisabled
+			case pos == cursor || childHoverID == optionID:
+				s = props.Styles.OptionCursor
+			}
+			if isSelected(index) {
+				s = s.Inherit(props.Styles.OptionSelected)
+			}
+			s = s.Inherit(menuBg)
+
+			mark := ""
+			if props.mode == modeMulti && isSelected(index) {
+				mark = "[x] "
+			} else if props.mode == modeMulti {
+				mark = "[ ] "
+			}
+			label := mark + runewidth.Truncate(option.Label, max(menuWidth-runewidth.StringWidth(mark), 0), "…")
+			lines = append(lines, c.MouseZoneChild(optionID, s.Width(menuWidth).MaxWidth(menuWidth).Render(label)))
+		}
+		app.UseOverlay(c, app.Overlay{
+			Content: props.Styles.Menu.Render(strings.Join(lines, "\n")),
+			Anchor:  app.UseBounds(c),
+			OnDismiss: func() {
+				state.open = false
+				c.Update()
+			},
+		})
+	}
+
+	return c.MouseZone(field)
+}
+
+// renderField renders the closed part of the dropdown on a single line.
+// Every part is styled on its own so the background of the field is kept
+// between the styled parts.
+func renderField(c *app.Ctx, props Props, state *dropdownState, fieldStyle lipgloss.Style, fieldWidth int, open bool) string {
+	innerWidth := fieldWidth - 3
+	text := fieldStyle.Render
+	placeholder := props.Styles.Placeholder.Inherit(fieldStyle).Render(props.Placeholder)
+
+	var content string
+	switch props.mode {
+	case modeSelect:
+		if props.Selected >= 0 && props.Selected < len(props.Options) {
+			content = text(runewidth.Truncate(props.Options[props.Selected].Label, innerWidth, "…"))
+		} else {
+			content = placeholder
+		}
+	case modeMulti:
+		chips := make([]string, 0, len(props.SelectedSet))
+		for _, index := range props.SelectedSet {
+			if index < 0 || index >= len(props.Options) {
+				continue
+			}
+			chip := props.Styles.Chip.Render(" " + props.Options[index].Label + " ✕")
+			chips = append(chips, c.MouseZoneChild("chip:"+strconv.Itoa(index), chip))
+		}
+		if len(chips) == 0 {
+			content = placeholder
+		} else {
+			content = ansi.Truncate(strings.Join(chips, text(" ")), innerWidth, "…")
+		}
+	case modeCombobox:
+		input := state.input
+		input.Placeholder = props.Placeholder
+		input.SetWidth(max(innerWidth-1, 1))
+		for _, s := range []*bubblestextinput.StyleState{&input.Styles.Focused, &input.Styles.Blurred} {
+			s.Text = fieldStyle
+			s.Prompt = fieldStyle
+			s.Placeholder = props.Styles.Placeholder.Inherit(fieldStyle)
+			s.Suggestion = props.Styles.Indicator.Inherit(fieldStyle)
+		}
+		content = ansi.Truncate(input.View(), innerWidth, "")
+	}
+
+	indicator := "▾"
+	if open {
+		indicator = "▴"
+	}
+	padding := max(innerWidth-ansi.StringWidth(content), 0)
+	return text(" ") + content + text(strings.Repeat(" ", padding)+" ") +
+		props.Styles.Indicator.Inherit(fieldStyle).Render(indicator)
+}
+
+// comboboxKey handles the keys of a combobox. Keys not used by the menu are
+// passed to the text input which also moves between the matching options
+// once something has been typed.
+func comboboxKey(c *app.Ctx, props Props, state *dropdownState, keyMsg tea.KeyMsg, open bool, hasOptions bool, cursor int,
+	setOpen func(bool), setCursor func(int), choose func(int)) bool {
+	input := state.input
+	typed := input.Value() != ""
+	switch {
+	case open && key.Matches(keyMsg, props.KeyMap.Close):
+		setOpen(false)
+		return true
+	case open && key.Matches(keyMsg, props.KeyMap.Choose):
+		choose(cursor)
+		return true
+	case typed && hasOptions && key.Matches(keyMsg, input.KeyMap.AcceptSuggestion):
+		choose(cursor)
+		return true
+	case !open && key.Matches(keyMsg, props.KeyMap.Down):
+		setOpen(true)
+		return true
+	case open && !typed && key.Matches(keyMsg, props.KeyMap.Up):
+		setCursor(nextEnabled(props.Options, allOptions(props.Options), cursor, -1))
+		return true
+	case open && !typed && key.Matches(keyMsg, props.KeyMap.Down):
+		setCursor(nextEnabled(props.Options, allOptions(props.Options), cursor, 1))
+		return true
+	}
+
+	switch keyMsg.String() {
+	case "tab", "shift+tab", "enter", "ctrl+c":
+		return false
+	}
+
+	before := input.Value()
+	newInput, cmd := input.Update(keyMsg)
+	*input = newInput
+	c.ExecuteCmd(cmd)
+	if value := input.Value(); value != before {
+		state.open = true
+		state.follow = true
+		if props.OnTextChange != nil {
+			props.OnTextChange(value)
+		}
+	}
+	state.follow = true
+	c.Update()
+	return true
+}
+
+// shownOptions returns the indexes of the options in the menu and the
+// position of the cursor among them. A combobox only shows the options
+// matching the typed text and the text input keeps track of the cursor.
+func shownOptions(props Props, state *dropdownState) ([]int, int) {
+	if props.mode != modeCombobox || state.input.Value() == "" {
+		shown := allOptions(props.Options)
+		return shown, clamp(state.cursor, -1, len(shown)-1)
+	}
+	matched := state.input.MatchedSuggestions()
+	shown := make([]int, 0, len(matched))
+	for i, option := range props.Options {
+		if len(shown) < len(matched) && !option.Disabled && option.Label == matched[len(shown)] {
+			shown = append(shown, i)
+		}
+	}
+	return shown, clamp(state.input.CurrentSuggestionIndex(), 0, len(shown)-1)
+}
+
+func allOptions(options []Option) []int {
+	shown := make([]int, len(options))
+	for i := range options {
+		shown[i] = i
+	}
+	return shown
+}
+
+// nextEnabled returns the position of the next enabled option from pos in
+// the direction. The position is kept when there are none.
+func nextEnabled(options []Option, shown []int, pos int, direction int) int {
+	for next := pos + direction; next >= 0 && next < len(shown); next += direction {
+		if !options[shown[next]].Disabled {
+			return next
+		}
+	}
+	return pos
+}
+
+// typeAhead moves to the next enabled option starting with the typed text.
+// Characters typed shortly after each other are combined into one search.
+func typeAhead(state *dropdownState, options []Option, shown []int, pos int, text string, move func(int)) {
+	start := pos + 1
+	if time.Since(state.typeAheadAt) < typeAheadTimeout {
+		state.typeAhead += text
+		start = max(pos, 0)
+	} else {
+		state.typeAhead = text
+	}
+	state.typeAheadAt = time.Now()
+
+	query := strings.ToLower(state.typeAhead)
+	for i := range shown {
+		next := (start + i) % len(shown)
+		option := options[shown[next]]
+		if !option.Disabled && strings.HasPrefix(strings.ToLower(option.Label), query) {
+			move(next)
+			return
+		}
+	}
+}
+
+// naturalWidth is the width of a select that fits the longest option.
+func naturalWidth(props Props) int {
+	width := runewidth.StringWidth(props.Placeholder)
+	for _, option := range props.Options {
+		width = max(width, runewidth.StringWidth(option.Label))
+	}
+	return width + 3
+}
+
+func clamp(v, low, high int) int {
+	return max(low, min(v, high))
+}
+
+func newProps(c *app.Ctx, m mode, opts []prop) Props {
+	p := Props{
+		Selected:  -1,
+		MaxHeight: 8,
+		KeyMap:    defaultKeyMap(),
+		Styles:    c.Theme.Dropdown,
+		Layout:    app.Layout{GrowX: m != modeSelect},
+		mode:      m,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&p)
+		}
+	}
+	return p
+}
+
+// New creates a select. The options are shown below the field when it is
+// opened and onChange is called with the index of the chosen option.
+// Selected is -1 when nothing is chosen.
+func New(c *app.Ctx, options []Option, selected int, onChange func(index int), opts ...prop) *app.C {
+	p := newProps(c, modeSelect, opts)
+	p.Options = options
+	p.Selected = selected
+	p.OnChange = onChange
+	return c.Render(Dropdown, p)
+}
+
+// NewCombobox creates a text field which shows the options starting with the
+// typed text. onChange is called with the text as it is typed and when an
+// option is picked. Use WithOnChange to get the index of picked options.
+func NewCombobox(c *app.Ctx, options []Option, value string, onChange func(text string), opts ...prop) *app.C {
+	p := newProps(c, modeCombobox, opts)
+	p.Options = options
+	p.Value = value
+	p.OnTextChange = onChange
+	return c.Render(Dropdown, p)
+}
+
+// NewMulti creates a multiselect showing the chosen options as chips.
+// onChange is called with the sorted indexes of the chosen options.
+func NewMulti(c *app.Ctx, options []Option, selected []int, onChange func(selected []int), opts ...prop) *app.C {
+	p := newProps(c, modeMulti, opts)
+	p.Options = options
+	p.SelectedSet = selected
+	p.OnSelectionChange = onChange
+	return c.Render(Dropdown, p)
+}
+
+func WithKey(key string) prop {
+	return func(p *Props) {
+		p.Key = key
+	}
+}
+
+func WithPlaceholder(placeholder string) prop {
+	return func(p *Props) {
+		p.Placeholder = placeholder
+	}
+}
+
+func WithDisabled(disabled bool) prop {
+	return func(p *Props) {
+		p.Disabled = disabled
+	}
+}
+
+// WithMaxHeight sets the most options shown in the menu at once.
+func WithMaxHeight(height int) prop {
+	return func(p *Props) {
+		p.MaxHeight = height
+	}
+}
+
+// WithOnChange sets the function called with the index of the chosen option.
+func WithOnChange(onChange func(index int)) prop {
+	return func(p *Props) {
+		p.OnChange = onChange
+	}
+}
+
+func WithKeyMap(keyMap KeyMap) prop {
+	return func(p *Props) {
+		p.KeyMap = keyMap
+	}
+}
+
+func WithStyles(styles style.DropdownTheme) prop {
+	return func(p *Props) {
+		p.Styles = styles
+	}
+}
+
+func WithWidth(width int) prop {
+	return func(p *Props) {
+		p.Layout.Width = width
+		p.Layout.GrowX = false
+	}
+}
+
+func WithGrowX(grow bool) prop {
+	return func(p *Props) {
+		p.Layout.GrowX = grow
+	}
+}
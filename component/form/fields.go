@@ -0,0 +1,184 @@
+package form
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/component/checkbox"
+	"github.com/alexanderbh/bubbleapp/component/dropdown"
+	"github.com/alexanderbh/bubbleapp/component/radio"
+	"github.com/alexanderbh/bubbleapp/component/stack"
+	"github.com/alexanderbh/bubbleapp/component/text"
+	"github.com/alexanderbh/bubbleapp/component/textfield"
+	"github.com/alexanderbh/bubbleapp/component/toggle"
+	"github.com/alexanderbh/bubbleapp/style"
+)
+
+// FieldProps holds the configuration shared by all fields of a form.
+type FieldProps struct {
+	Form  *Form
+	Name  string
+	Label string
+	// Validators run every time the value changes.
+	Validators []Validator
+	// AsyncValidators run in the background once the value passes the other
+	// validators and the field has been touched.
+	AsyncValidators []Validator
+	// Content renders the input of the field.
+	Content app.FC
+	app.Layout
+}
+
+type fieldProp func(*FieldProps)
+
+func WithLabel(label string) fieldProp {
+	return func(p *FieldProps) {
+		p.Label = label
+	}
+}
+
+func WithValidate(validators ...Validator) fieldProp {
+	return func(p *FieldProps) {
+		p.Validators = append(p.Validators, validators...)
+	}
+}
+
+// WithAsyncValidate adds validators that are run in a goroutine. Use them for
+// slow checks like asking a server.
+func WithAsyncValidate(validators ...Validator) fieldProp {
+	return func(p *FieldProps) {
+		p.AsyncValidators = append(p.AsyncValidators, validators...)
+	}
+}
+
+func newFieldProps(f *Form, name string, opts []fieldProp) FieldProps {
+	p := FieldProps{
+		Form:   f,
+		Name:   name,
+		Layout: app.Layout{GrowX: true},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&p)
+		}
+	}
+	return p
+}
+
+// Field renders the label, the input and the error of a field. The field is
+// touched when the focus leaves the input.
+func Field(c *app.Ctx, rawProps app.Props) string {
+	props, ok := rawProps.(FieldProps)
+	if !ok {
+		panic("Field: props must be of type form.FieldProps")
+	}
+	f := props.Form
+
+	id := app.UseID(c)
+	f.register(props.Name, props.Validators, props.AsyncValidators)
+
+	focused := c.UIState.Focused
+	focusedWithin := focused == id || strings.HasPrefix(focused, id+"_")
+	wasFocused, _ := app.UseState(c, new(bool))
+	app.UseEffect(c, func() {
+		if *wasFocused && !focusedWithin {
+			f.Touch(props.Name)
+		}
+		*wasFocused = focusedWithin
+	}, []any{focusedWithin})
+
+	return stack.New(c, func(c *app.Ctx) []*app.C {
+		cs := []*app.C{}
+		if props.Label != "" {
+			cs = append(cs, text.New(c, props.Label, text.WithBold(true)))
+		}
+		cs = append(cs, props.Content(c))
+		if message := f.Error(props.Name); message != "" {
+			cs = append(cs, text.New(c, message, text.WithVariant(style.Danger)))
+		} else if f.Validating(props.Name) {
+			cs = append(cs, text.New(c, "Checking…", text.WithVariant(style.Info)))
+		}
+		return cs
+	}, stack.WithGrowY(false)).String()
+}
+
+// TextField creates a text field bound to the string value of the field with
+// the name. Pressing enter submits the form.
+func TextField(c *app.Ctx, f *Form, name string, opts ...fieldProp) *app.C {
+	p := newFieldProps(f, name, opts)
+	p.Content = func(c *app.Ctx) *app.C {
+		return textfield.New(c, func(text string) {
+			f.SetValue(name, text)
+		}, Get[string](f.values, name), textfield.WithOnEnter(f.Submit))
+	}
+	return c.Render(Field, p)
+}
+
+// Password creates a text field bound to the string value of the field with
+// the name. The typed characters are masked.
+func Password(c *app.Ctx, f *Form, name string, opts ...fieldProp) *app.C {
+	p := newFieldProps(f, name, opts)
+	p.Content = func(c *app.Ctx) *app.C {
+		return textfield.New(c, func(text string) {
+			f.SetValue(name, text)
+		}, Get[string](f.values, name), textfield.WithOnEnter(f.Submit), textfield.WithEchoMode(textfield.EchoPassword))
+	}
+	return c.Render(Field, p)
+}
+
+// Checkbox creates a checkbox bound to the bool value of the field with the
+// name. The label is shown next to the box.
+func Checkbox(c *app.Ctx, f *Form, name string, label string, opts ...fieldProp) *app.C {
+	p := newFieldProps(f, name, opts)
+	p.Content = func(c *app.Ctx) *app.C {
+		return checkbox.New(c, label, Get[bool](f.values, name), func(checked bool) {
+			f.SetValue(name, checked)
+		})
+	}
+	return c.Render(Field, p)
+}
+
+// Toggle creates a toggle switch bound to the bool value of the field with the
+// name. The label is shown next to the switch.
+func Toggle(c *app.Ctx, f *Form, name string, label string, opts ...fieldProp) *app.C {
+	p := newFieldProps(f, name, opts)
+	p.Content = func(c *app.Ctx) *app.C {
+		return toggle.New(c, label, Get[bool](f.values, name), func(on bool) {
+			f.SetValue(name, on)
+		})
+	}
+	return c.Render(Field, p)
+}
+
+// Radio creates a group of choices bound to the string value of the field
+// with the name.
+func Radio(c *app.Ctx, f *Form, name string, choices []string, opts ...fieldProp) *app.C {
+	p := newFieldProps(f, name, opts)
+	p.Content = func(c *app.Ctx) *app.C {
+		return radio.New(c, choices, slices.Index(choices, Get[string](f.values, name)), func(index int) {
+			f.SetValue(name, choices[index])
+		})
+	}
+	return c.Render(Field, p)
+}
+
+// Select creates a dropdown bound to the field with the name. The value of the
+// field is the Value of the chosen option.
+func Select(c *app.Ctx, f *Form, name string, options []dropdown.Option, opts ...fieldProp) *app.C {
+	p := newFieldProps(f, name, opts)
+	p.Content = func(c *app.Ctx) *app.C {
+		selected := -1
+		for i, option := range options {
+			if reflect.DeepEqual(option.Value, f.values[name]) {
+				selected = i
+				break
+			}
+		}
+		return dropdown.New(c, options, selected, func(index int) {
+			f.SetValue(name, options[index].Value)
+		})
+	}
+	return c.Render(Field, p)
+}
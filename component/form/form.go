@@ -1,175 +1,272 @@
 package form
 
 import (
-	"strings"
-	"unicode"
+	"maps"
+	"reflect"
+	"slices"
 
 	"github.com/alexanderbh/bubbleapp/app"
-	tea "github.com/charmbracelet/bubbletea/v2"
-	"github.com/charmbracelet/huh/v2"
+	"github.com/alexanderbh/bubbleapp/component/stack"
 )
 
-type Props struct {
-	HuhForm  *huh.Form
-	OnSubmit func() // Can this somehow pass the form data? Or should values be set from parent?
-	app.Layout
+// Values holds the values of the fields in a form by name.
+type Values map[string]any
+
+// Get returns the value of the field with the name as the type T. The zero
+// value is returned if the field has no value or a value of another type.
+func Get[T any](values Values, name string) T {
+	value, _ := values[name].(T)
+	return value
 }
 
-type prop func(*Props)
+// Validator checks the value of a field. The message of the returned error
+// is shown below the field.
+type Validator func(value any) error
 
-func New(c *app.Ctx, huhForm *huh.Form, onSubmit func(), opts ...prop) *app.C {
-	appliedProps := Props{
-		HuhForm:  huhForm,
-		OnSubmit: onSubmit,
-	}
+// Form holds the values and the validation state of a form. It is created
+// with UseForm and the fields are bound to it by name.
+type Form struct {
+	c        *app.Ctx
+	onSubmit func(values Values)
 
-	for _, opt := range opts {
-		if opt != nil {
-			opt(&appliedProps)
-		}
-	}
+	initial Values
+	values  Values
+	errors  map[string]string
+	touched map[string]bool
+
+	validators      map[string][]Validator
+	asyncValidators map[string][]Validator
+	// generation is increased every time a field is validated so results of
+	// async validators of older values are ignored.
+	generation map[string]int
+	pending    map[string]bool
 
-	return c.Render(form, appliedProps)
+	submitted  bool
+	submitting bool
 }
 
-func form(c *app.Ctx, props app.Props) string {
-	p, ok := props.(Props)
-	if !ok {
-		panic("Form: incorrect props type")
+// UseForm creates a form with the initial values. onSubmit is called with the
+// values when the form is submitted and all fields are valid.
+func UseForm(c *app.Ctx, initial Values, onSubmit func(values Values)) *Form {
+	f, _ := app.UseState(c, &Form{
+		initial:         maps.Clone(initial),
+		values:          maps.Clone(initial),
+		errors:          make(map[string]string),
+		touched:         make(map[string]bool),
+		validators:      make(map[string][]Validator),
+		asyncValidators: make(map[string][]Validator),
+		generation:      make(map[string]int),
+		pending:         make(map[string]bool),
+	})
+	if f.values == nil {
+		f.values = make(Values)
 	}
+	f.c = c
+	f.onSubmit = onSubmit
+	return f
+}
 
-	_ = app.UseIsFocused(c)
+// register sets the validators of a field. Fields call it on every render.
+func (f *Form) register(name string, validators []Validator, asyncValidators []Validator) {
+	f.validators[name] = validators
+	f.asyncValidators[name] = asyncValidators
+}
 
-	activeForm, setActiveForm := app.UseState(c, p.HuhForm)
+// Value returns the value of the field with the name.
+func (f *Form) Value(name string) any {
+	return f.values[name]
+}
 
-	app.UseOnFocused(c, func(isReverse bool) {
-		if activeForm == nil {
-			return
+// Values returns a copy of the values of all fields.
+func (f *Form) Values() Values {
+	return maps.Clone(f.values)
+}
+
+// SetValue changes the value of a field and validates it.
+func (f *Form) SetValue(name string, value any) {
+	f.values[name] = value
+	f.validate(name, f.touched[name] || f.submitted)
+	f.c.Update()
+}
+
+// Touch marks the field as touched which shows its errors. Fields are touched
+// when the focus leaves them.
+func (f *Form) Touch(name string) {
+	if f.touched[name] {
+		return
+	}
+	f.touched[name] = true
+	f.validate(name, true)
+	f.c.Update()
+}
+
+// Touched reports whether the focus has left the field.
+func (f *Form) Touched(name string) bool {
+	return f.touched[name]
+}
+
+// Dirty reports whether the value of the field differs from the initial value.
+// With no names it reports whether any field is dirty.
+func (f *Form) Dirty(names ...string) bool {
+	if len(names) == 0 {
+		for name := range f.values {
+			names = append(names, name)
 		}
-		var cmds []tea.Cmd
-		cmds = append(cmds, activeForm.Init())
-
-		// This will handle the one case where user is tabbing forward
-		// and the form is on the last field. User continues to tab forward
-		// and wraps around to the form again. This will move the focus
-		// to the first field.
-
-		// Unfortunately is it not possible with the current API to do the same
-		// for the reverse case. If I move to NextField it will end up Submitting
-		// the form. And it is not possible to know from the outside where the focus
-		// is in the form.
-		if !isReverse {
-			for range 20 {
-				cmds = append(cmds, activeForm.PrevField())
-			}
+	}
+	for _, name := range names {
+		if !reflect.DeepEqual(f.values[name], f.initial[name]) {
+			return true
 		}
-		c.ExecuteCmd(tea.Batch(cmds...))
-	})
+	}
+	return false
+}
 
-	app.UseEffect(c, func() {
-		if p.HuhForm != activeForm {
-			setActiveForm(p.HuhForm)
-		}
-	}, []any{p.HuhForm})
+// Error returns the error message of the field once it has been touched or
+// the form has been submitted.
+func (f *Form) Error(name string) string {
+	if !f.touched[name] && !f.submitted {
+		return ""
+	}
+	return f.errors[name]
+}
 
-	app.UseEffect(c, func() {
-		if activeForm == nil {
-			return
-		}
+// Validating reports whether async validators are running for the field.
+func (f *Form) Validating(name string) bool {
+	return f.pending[name]
+}
 
-		activeForm.SubmitCmd = func() tea.Msg {
-			if p.OnSubmit != nil {
-				p.OnSubmit()
-			}
-			c.Update()
-			return nil
+// Valid reports whether all fields are valid. Fields still being validated
+// are not valid yet.
+func (f *Form) Valid() bool {
+	for _, message := range f.errors {
+		if message != "" {
+			return false
 		}
+	}
+	return len(f.pending) == 0
+}
 
-		initCmd := activeForm.Init()
-		if initCmd != nil {
-			c.ExecuteCmd(initCmd)
-		}
+// Submitting reports whether the form waits for async validators before
+// calling OnSubmit.
+func (f *Form) Submitting() bool {
+	return f.submitting
+}
 
-		currentInstanceID := app.UseID(c)
-		c.UIState.Focused = currentInstanceID
+// Submit validates all fields and calls OnSubmit when they are valid. When
+// async validators are running OnSubmit is called once they are done.
+func (f *Form) Submit() {
+	f.submitted = true
+	names := slices.Sorted(maps.Keys(f.validators))
+	for _, name := range names {
+		f.touched[name] = true
+		f.validate(name, true)
+	}
+	f.submitting = true
+	f.finishSubmit()
+	f.c.Update()
+}
 
-	}, []any{activeForm, p.OnSubmit})
+// Reset sets the fields back to the initial values and clears the errors and
+// the touched state.
+func (f *Form) Reset() {
+	f.values = maps.Clone(f.initial)
+	if f.values == nil {
+		f.values = make(Values)
+	}
+	clear(f.errors)
+	clear(f.touched)
+	clear(f.pending)
+	for name := range f.generation {
+		f.generation[name]++
+	}
+	f.submitted, f.submitting = false, false
+	f.c.Update()
+}
 
-	app.UseMsgHandler(c, func(msg tea.Msg) tea.Cmd {
-		if activeForm == nil {
-			return nil
-		}
+// validate runs the validators of the field. The async validators only run
+// when the value passes the others.
+func (f *Form) validate(name string, async bool) {
+	f.generation[name]++
+	delete(f.pending, name)
+	delete(f.errors, name)
 
-		if updatedFormModel, cmd := activeForm.Update(msg); cmd != nil {
-			if updatedTypedForm, ok := updatedFormModel.(*huh.Form); ok {
-				setActiveForm(updatedTypedForm)
-			}
-			return cmd
+	value := f.values[name]
+	for _, validator := range f.validators[name] {
+		if err := validator(value); err != nil {
+			f.errors[name] = err.Error()
+			return
 		}
-		return nil
-	})
+	}
 
-	app.UseKeyHandler(c, func(msg tea.KeyMsg) bool {
-		if activeForm == nil {
-			return false
+	asyncValidators := f.asyncValidators[name]
+	if !async || len(asyncValidators) == 0 {
+		return
+	}
+	generation := f.generation[name]
+	f.pending[name] = true
+	f.c.Go(func() func() {
+		var message string
+		for _, validator := range asyncValidators {
+			if err := validator(value); err != nil {
+				message = err.Error()
+				break
+			}
 		}
-		switch msg.String() {
-		case "ctrl+c": // Do not exit on ctrl+c (part of default keymap for huh.Form for some reason)
-			return false
-		case "shift+tab":
-			beforeTab := activeForm.GetFocusedField().GetKey()
-			activeForm.PrevField()
-			afterTab := activeForm.GetFocusedField().GetKey()
-			if beforeTab == afterTab {
-				return false
+		return func() {
+			if f.generation[name] != generation {
+				return
 			}
-			activeForm.NextField()
-		case "tab":
-			beforeTab := activeForm.GetFocusedField().GetKey()
-			activeForm.NextField()
-			afterTab := activeForm.GetFocusedField().GetKey()
-			if beforeTab == afterTab {
-				return false
+			delete(f.pending, name)
+			if message != "" {
+				f.errors[name] = message
 			}
-			activeForm.PrevField()
+			f.finishSubmit()
 		}
+	})
+}
 
-		updatedFormModel, cmd := activeForm.Update(msg)
+// finishSubmit calls OnSubmit for a submit waiting on async validators once
+// they are all done.
+func (f *Form) finishSubmit() {
+	if !f.submitting || len(f.pending) > 0 {
+		return
+	}
+	f.submitting = false
+	if f.Valid() && f.onSubmit != nil {
+		f.onSubmit(f.Values())
+	}
+}
 
-		if updatedTypedForm, ok := updatedFormModel.(*huh.Form); ok {
-			setActiveForm(updatedTypedForm)
-		}
+type Props struct {
+	FCs app.FCs
+	app.Layout
+}
 
-		if cmd != nil {
-			c.ExecuteCmd(cmd)
-		}
-		// Return if key was handled or not
-		switch msg := msg.(type) {
-		case tea.KeyMsg:
-			switch msg.String() {
-			case "shift+tab":
-				return true
-			case "tab":
-				return true
-			}
+type prop func(*Props)
+
+// New renders the fields of a form below each other. The fields can also be
+// placed anywhere else as they are bound to the form by name.
+func New(c *app.Ctx, fcs app.FCs, opts ...prop) *app.C {
+	p := Props{
+		FCs:    fcs,
+		Layout: app.Layout{GrowX: true},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&p)
 		}
-		return false
-	})
+	}
+	return stack.New(c, p.FCs, stack.WithGap(p.GapY), stack.WithGrowY(p.GrowY))
+}
 
-	if activeForm == nil {
-		return ""
+func WithGapY(gap int) prop {
+	return func(p *Props) {
+		p.Layout.GapY = gap
 	}
-	view := activeForm.View()
+}
 
-	// Remove the last newline from the form output. huhForm has a new line at the end unfortunately.
-	lastNewlineIdx := strings.LastIndexByte(view, '\n')
-	if lastNewlineIdx == -1 {
-		return view
+func WithGrowY(grow bool) prop {
+	return func(p *Props) {
+		p.Layout.GrowY = grow
 	}
-	preNewlinePart := view[:lastNewlineIdx]
-	postNewlinePart := view[lastNewlineIdx+1:]
-	trimmedPreNewlinePart := strings.TrimRightFunc(preNewlinePart, func(r rune) bool {
-		return r != '\n' && unicode.IsSpace(r)
-	})
-	return trimmedPreNewlinePart + postNewlinePart
 }
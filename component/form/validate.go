@@ -0,0 +1,60 @@
+package form
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"unicode/utf8"
+)
+
+// Required fails for empty values like "", false, nil and empty slices.
+func Required(message string) Validator {
+	return func(value any) error {
+		if value == nil {
+			return errors.New(message)
+		}
+		v := reflect.ValueOf(value)
+		switch v.Kind() {
+		case reflect.String, reflect.Slice, reflect.Map:
+			if v.Len() == 0 {
+				return errors.New(message)
+			}
+		case reflect.Bool:
+			if !v.Bool() {
+				return errors.New(message)
+			}
+		}
+		return nil
+	}
+}
+
+// MinLength fails for strings shorter than min characters.
+func MinLength(min int) Validator {
+	return func(value any) error {
+		if s, _ := value.(string); utf8.RuneCountInString(s) < min {
+			return fmt.Errorf("Must be at least %d characters", min)
+		}
+		return nil
+	}
+}
+
+// MaxLength fails for strings longer than max characters.
+func MaxLength(max int) Validator {
+	return func(value any) error {
+		if s, _ := value.(string); utf8.RuneCountInString(s) > max {
+			return fmt.Errorf("Must be at most %d characters", max)
+		}
+		return nil
+	}
+}
+
+// Match fails for strings not matching the pattern.
+func Match(pattern *regexp.Regexp, message string) Validator {
+	return func(value any) error {
+		if s, _ := value.(string); !pattern.MatchString(s) {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
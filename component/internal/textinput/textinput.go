@@ -7,7 +7,7 @@ import (
 	"strings"
 	"unicode"
 
-	"github.com/alexanderbh/bubbleapp/component/textfield/internal/runeutil"
+	"github.com/alexanderbh/bubbleapp/component/internal/runeutil"
 	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/v2/cursor"
 	"github.com/charmbracelet/bubbles/v2/key"
@@ -550,7 +550,9 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	keyMsg, ok := msg.(tea.KeyPressMsg)
 	if ok && key.Matches(keyMsg, m.KeyMap.AcceptSuggestion) {
 		if m.canAcceptSuggestion() {
-			m.value = append(m.value, m.matchedSuggestions[m.currentSuggestionIndex][len(m.value):]...)
+			// Suggestions match regardless of case so the typed text takes the
+			// case of the suggestion.
+			m.value = append([]rune(nil), m.matchedSuggestions[m.currentSuggestionIndex]...)
 			m.CursorEnd()
 		}
 	}
@@ -641,16 +643,11 @@ func (m Model) View() string {
 	pos := max(0, m.pos-m.offset)
 	v := styleText(m.echoTransform(string(value[:pos])))
 
-	if pos < len(value) { // cursor and text under it
-		v += styleText(m.echoTransform(string(value[pos+1:]))) // text after cursor
-		v += m.completionView(0)                               // suggested completion
-	} else {
-		if m.focus && m.canAcceptSuggestion() {
-			suggestion := m.matchedSuggestions[m.currentSuggestionIndex]
-			if len(value) < len(suggestion) {
-				v += m.completionView(1)
-			}
-		}
+	if pos < len(value) { // the real cursor is drawn on top of the text under it
+		v += styleText(m.echoTransform(string(value[pos:]))) // text under and after cursor
+		v += m.completionView(0)                             // suggested completion
+	} else if m.focus {
+		v += m.completionView(0)
 	}
 
 	// If a max width and background color were set fill the empty spaces with
@@ -671,42 +668,19 @@ func (m Model) promptView() string {
 	return m.activeStyle().Prompt.Render(m.Prompt)
 }
 
-// placeholderView returns the prompt and placeholder view, if any.
+// placeholderView returns the prompt and placeholder view, if any. The real
+// cursor is drawn on top of the first character.
 func (m Model) placeholderView() string {
-	var (
-		v      string
-		styles = m.activeStyle()
-		render = styles.Placeholder.Render
-	)
-
-	p := make([]rune, m.Width()+1)
-	copy(p, []rune(m.Placeholder))
-
-	// If the entire placeholder is already set and no padding is needed, finish
-	if m.Width() < 1 && len(p) <= 1 {
-		return styles.Prompt.Render(m.Prompt) + v
-	}
+	styles := m.activeStyle()
+	render := styles.Placeholder.Render
 
-	// If Width is set then size placeholder accordingly
+	v := m.Placeholder
 	if m.Width() > 0 {
-		// available width is width - len + cursor offset of 1
-		minWidth := lipgloss.Width(m.Placeholder)
-		availWidth := m.Width() - minWidth + 1
-
-		// if width < len, 'subtract'(add) number to len and dont add padding
-		if availWidth < 0 {
-			minWidth += availWidth
-			availWidth = 0
-		}
-		// append placeholder[len] - cursor, append padding
-		v += render(string(p[1:minWidth]))
-		v += render(strings.Repeat(" ", availWidth))
-	} else {
-		// if there is no width, the placeholder can be any length
-		v += render(string(p[1:]))
+		v = rw.Truncate(v, m.Width(), "")
+		v += strings.Repeat(" ", max(0, m.Width()-lipgloss.Width(v)+1))
 	}
 
-	return styles.Prompt.Render(m.Prompt) + v
+	return styles.Prompt.Render(m.Prompt) + render(v)
 }
 
 // Blink is a command used to initialize cursor blinking.
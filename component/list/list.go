@@ -0,0 +1,539 @@
+package list
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/style"
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// Item is passed to the item renderer for every visible item.
+type Item struct {
+	Index       int
+	Cursor      bool
+	Selected    bool
+	Hovered     bool
+	ListFocused bool
+}
+
+// ItemFC renders a single item of the list.
+type ItemFC = func(c *app.Ctx, item Item) *app.C
+
+// Props holds the configuration for the List component.
+type Props struct {
+	Key       string
+	ItemCount int
+	Item      ItemFC
+	// ItemHeight returns the height in lines of the item at the given index.
+	// Items are clipped to this height. If nil every item is one line high.
+	ItemHeight        func(index int) int
+	Multi             bool
+	Scrollbar         bool
+	WheelDelta        int
+	OnCursorChange    func(index int)
+	OnSelectionChange func(selected []int)
+	OnActivate        func(index int)
+	KeyMap            KeyMap
+	Styles            style.ListTheme
+	app.Layout
+}
+
+type prop func(*Props)
+
+type KeyMap struct {
+	LineUp       key.Binding
+	LineDown     key.Binding
+	PageUp       key.Binding
+	PageDown     key.Binding
+	HalfPageUp   key.Binding
+	HalfPageDown key.Binding
+	GotoTop      key.Binding
+	GotoBottom   key.Binding
+	Toggle       key.Binding
+	SelectAll    key.Binding
+	SelectNone   key.Binding
+	Activate     key.Binding
+}
+
+func (km KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{km.LineUp, km.LineDown, km.Toggle, km.Activate}
+}
+
+func (km KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{km.LineUp, km.LineDown, km.GotoTop, km.GotoBottom},
+		{km.PageUp, km.PageDown, km.HalfPageUp, km.HalfPageDown},
+		{km.Toggle, km.SelectAll, km.SelectNone, km.Activate},
+	}
+}
+
+func defaultKeyMap() KeyMap {
+	return KeyMap{
+		LineUp: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		LineDown: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		PageUp: key.NewBinding(
+			key.WithKeys("b", "pgup"),
+			key.WithHelp("b/pgup", "page up"),
+		),
+		PageDown: key.NewBinding(
+			key.WithKeys("f", "pgdown"),
+			key.WithHelp("f/pgdn", "page down"),
+		),
+		HalfPageUp: key.NewBinding(
+			key.WithKeys("u", "ctrl+u"),
+			key.WithHelp("u", "½ page up"),
+		),
+		HalfPageDown: key.NewBinding(
+			key.WithKeys("d", "ctrl+d"),
+			key.WithHelp("d", "½ page down"),
+		),
+		GotoTop: key.NewBinding(
+			key.WithKeys("home", "g"),
+			key.WithHelp("g/home", "go to start"),
+		),
+		GotoBottom: key.NewBinding(
+			key.WithKeys("end", "G"),
+			key.WithHelp("G/end", "go to end"),
+		),
+		Toggle: key.NewBinding(
+			key.WithKeys("space"),
+			key.WithHelp("space", "toggle selection"),
+		),
+		SelectAll: key.NewBinding(
+			key.WithKeys("ctrl+a"),
+			key.WithHelp("ctrl+a", "select all"),
+		),
+		SelectNone: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "clear selection"),
+		),
+		Activate: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "activate"),
+		),
+	}
+}
+
+// listState is mutated in place while rendering to keep the cursor in view.
+// Event handlers call c.Update() after changing it.
+type listState struct {
+	top      int
+	cursor   int
+	follow   bool
+	visible  int
+	selected map[int]struct{}
+}
+
+// List is the functional component for rendering a virtualized list.
+// Only the items that fit in the height given by the layout are rendered.
+func List(c *app.Ctx, rawProps app.Props) string {
+	props, ok := rawProps.(Props)
+	if !ok {
+		panic("List: props must be of type list.Props")
+	}
+
+	id := app.UseID(c)
+	isFocused := app.UseIsFocused(c)
+	_, childHoverID := app.UseIsHovered(c)
+	state, _ := app.UseState(c, &listState{selected: make(map[int]struct{})})
+
+	width, height := app.UseSize(c)
+	count := props.ItemCount
+
+	itemHeight := func(i int) int {
+		if props.ItemHeight == nil {
+			return 1
+		}
+		return max(props.ItemHeight(i), 1)
+	}
+
+	setCursor := func(cursor int) {
+		if count == 0 {
+			return
+		}
+		cursor = clamp(cursor, 0, count-1)
+		state.follow = true
+		if cursor == state.cursor {
+			return
+		}
+		state.cursor = cursor
+		c.Update()
+		if props.OnCursorChange != nil {
+			props.OnCursorChange(cursor)
+		}
+		if !props.Multi && props.OnSelectionChange != nil {
+			props.OnSelectionChange([]int{cursor})
+		}
+	}
+
+	selectionChanged := func() {
+		c.Update()
+		if props.OnSelectionChange != nil {
+			props.OnSelectionChange(selectedIndices(state.selected))
+		}
+	}
+
+	toggle := func(index int) {
+		if _, ok := state.selected[index]; ok {
+			delete(state.selected, index)
+		} else {
+			state.selected[index] = struct{}{}
+		}
+		selectionChanged()
+	}
+
+	app.UseKeyHandler(c, func(keyMsg tea.KeyMsg) bool {
+		page := max(state.visible, 1)
+		switch {
+		case key.Matches(keyMsg, props.KeyMap.LineUp):
+			setCursor(state.cursor - 1)
+		case key.Matches(keyMsg, props.KeyMap.LineDown):
+			setCursor(state.cursor + 1)
+		case key.Matches(keyMsg, props.KeyMap.PageUp):
+			setCursor(state.cursor - page)
+		case key.Matches(keyMsg, props.KeyMap.PageDown):
+			setCursor(state.cursor + page)
+		case key.Matches(keyMsg, props.KeyMap.HalfPageUp):
+			setCursor(state.cursor - max(page/2, 1))
+		case key.Matches(keyMsg, props.KeyMap.HalfPageDown):
+			setCursor(state.cursor + max(page/2, 1))
+		case key.Matches(keyMsg, props.KeyMap.GotoTop):
+			setCursor(0)
+		case key.Matches(keyMsg, props.KeyMap.GotoBottom):
+			setCursor(count - 1)
+		case props.Multi && key.Matches(keyMsg, props.KeyMap.Toggle):
+			if count == 0 {
+				return false
+			}
+			toggle(state.cursor)
+		case props.Multi && key.Matches(keyMsg, props.KeyMap.SelectAll):
+			for i := range count {
+				state.selected[i] = struct{}{}
+			}
+			selectionChanged()
+		case props.Multi && key.Matches(keyMsg, props.KeyMap.SelectNone):
+			if len(state.selected) == 0 {
+				return false
+			}
+			clear(state.selected)
+			selectionChanged()
+		case key.Matches(keyMsg, props.KeyMap.Activate):
+			if props.OnActivate == nil || count == 0 {
+				return false
+			}
+			props.OnActivate(state.cursor)
+		default:
+			return false
+		}
+		return true
+	})
+
+	app.UseMouseHandler(c, func(msg tea.MouseMsg, childID string) bool {
+		switch msg := msg.(type) {
+		case tea.MouseWheelMsg:
+			delta := max(props.WheelDelta, 1)
+			switch msg.Button {
+			case tea.MouseWheelDown:
+				if state.top+state.visible >= count {
+					return false
+				}
+				state.top = min(state.top+delta, count-1)
+			case tea.MouseWheelUp:
+				if state.top <= 0 {
+					return false
+				}
+				state.top = max(state.top-delta, 0)
+			default:
+				return false
+			}
+			state.follow = false
+			c.Update()
+			return true
+		case tea.MouseReleaseMsg:
+			if msg.Button != tea.MouseLeft || !strings.HasPrefix(childID, "row:") {
+				return false
+			}
+			index, err := strconv.Atoi(strings.TrimPrefix(childID, "row:"))
+			if err != nil || index < 0 || index >= count {
+				return false
+			}
+			c.FocusThis(id)
+			setCursor(index)
+			if props.Multi {
+				toggle(index)
+			}
+			return true
+		}
+		return false
+	})
+
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+
+	rowWidth := width
+	if props.Scrollbar {
+		rowWidth = max(width-1, 0)
+	}
+
+	// Keep the cursor and the window inside the list. The sizes are only
+	// final in the last layout phase so the state is not touched before that.
+	cursor, top := 0, 0
+	if count > 0 {
+		cursor = clamp(state.cursor, 0, count-1)
+		top = clamp(state.top, 0, count-1)
+	}
+	if state.follow && count > 0 {
+		if cursor < top {
+			top = cursor
+		} else {
+			used := 0
+			for i := top; i <= cursor; i++ {
+				used += itemHeight(i)
+			}
+			if used > height {
+				top = cursor
+				used = itemHeight(top)
+				for top > 0 && used+itemHeight(top-1) <= height {
+					top--
+					used += itemHeight(top)
+				}
+			}
+		}
+	}
+	// Avoid empty space at the bottom when scrolled past the end.
+	used := 0
+	for i := top; i < count && used < height; i++ {
+		used += itemHeight(i)
+	}
+	for top > 0 && used+itemHeight(top-1) <= height {
+		top--
+		used += itemHeight(top)
+	}
+	if c.LayoutPhase == app.LayoutPhaseFinalRender {
+		state.cursor, state.top, state.follow = cursor, top, false
+	}
+
+	lines := make([]string, 0, height)
+	visible := 0
+	for i := top; i < count && len(lines) < height; i++ {
+		h := min(itemHeight(i), height-len(lines))
+		_, selected := state.selected[i]
+		rowID := "row:" + strconv.Itoa(i)
+		item := Item{
+			Index:       i,
+			Cursor:      i == cursor,
+			Selected:    selected,
+			Hovered:     rowID == childHoverID,
+			ListFocused: isFocused,
+		}
+
+		s := props.Styles.Item
+		if item.Selected {
+			s = s.Inherit(props.Styles.Selected)
+		}
+		if item.Hovered {
+			s = s.Inherit(props.Styles.Hovered)
+		} else if item.Cursor && isFocused {
+			s = s.Inherit(props.Styles.CursorFocus)
+		} else if item.Cursor {
+			s = s.Inherit(props.Styles.Cursor)
+		}
+		if c.CurrentBg != nil {
+			s = s.Inherit(lipgloss.NewStyle().Background(c.CurrentBg))
+		}
+
+		content := c.Render(row, rowProps{
+			Key:  strconv.Itoa(i),
+			Item: props.Item,
+			Data: item,
+		}).String()
+		rendered := s.Width(rowWidth).MaxWidth(rowWidth).Height(h).MaxHeight(h).Render(content)
+		lines = append(lines, strings.Split(c.MouseZoneChild(rowID, rendered), "\n")...)
+		if itemHeight(i) <= h {
+			visible++
+		}
+	}
+	if c.LayoutPhase == app.LayoutPhaseFinalRender {
+		state.visible = visible
+	}
+
+	s := lipgloss.NewStyle()
+	if c.CurrentBg != nil {
+		s = s.Background(c.CurrentBg)
+	}
+	content := s.Width(rowWidth).Height(height).Render(strings.Join(lines, "\n"))
+
+	if props.Scrollbar {
+		content = lipgloss.JoinHorizontal(lipgloss.Top, content, scrollbar(props.Styles, height, top, visible, count))
+	}
+
+	return c.MouseZone(content)
+}
+
+type rowProps struct {
+	Key  string
+	Item ItemFC
+	Data Item
+	app.Layout
+}
+
+// row wraps each item so its state follows the item index instead of the
+// position on screen.
+func row(c *app.Ctx, rawProps app.Props) string {
+	props, _ := rawProps.(rowProps)
+	if props.Item == nil {
+		return ""
+	}
+	return props.Item(c, props.Data).String()
+}
+
+// scrollbar renders a vertical scrollbar based on item indices.
+func scrollbar(styles style.ListTheme, height, top, visible, count int) string {
+	if height <= 0 {
+		return ""
+	}
+	thumbSize, thumbStart := height, 0
+	if count > visible && count > 0 {
+		thumbSize = max(height*visible/count, 1)
+		thumbStart = min(height*top/count, height-thumbSize)
+	}
+	cells := make([]string, height)
+	for i := range cells {
+		if i >= thumbStart && i < thumbStart+thumbSize {
+			cells[i] = styles.ScrollThumb.Render("┃")
+		} else {
+			cells[i] = styles.Scrollbar.Render("│")
+		}
+	}
+	return strings.Join(cells, "\n")
+}
+
+func selectedIndices(selected map[int]struct{}) []int {
+	indices := make([]int, 0, len(selected))
+	for i := range selected {
+		indices = append(indices, i)
+	}
+	slices.Sort(indices)
+	return indices
+}
+
+func clamp(v, low, high int) int {
+	if high < low {
+		return low
+	}
+	return min(max(v, low), high)
+}
+
+// New creates a new virtualized list with itemCount items rendered by item.
+func New(c *app.Ctx, itemCount int, item ItemFC, opts ...prop) *app.C {
+	p := Props{
+		ItemCount:  itemCount,
+		Item:       item,
+		WheelDelta: 3,
+		KeyMap:     defaultKeyMap(),
+		Styles:     c.Theme.List,
+		Layout: app.Layout{
+			GrowX: true,
+			GrowY: true,
+		},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&p)
+		}
+	}
+	return c.Render(List, p)
+}
+
+// --- Prop Option Functions ---
+
+func WithKey(key string) prop {
+	return func(props *Props) {
+		props.Key = key
+	}
+}
+
+// WithItemHeight sets a function returning the height in lines of each item.
+func WithItemHeight(itemHeight func(index int) int) prop {
+	return func(props *Props) {
+		props.ItemHeight = itemHeight
+	}
+}
+
+// WithMulti enables selecting multiple items with space and mouse clicks.
+func WithMulti(multi bool) prop {
+	return func(props *Props) {
+		props.Multi = multi
+	}
+}
+
+// WithScrollbar shows a scrollbar on the right side of the list.
+func WithScrollbar(scrollbar bool) prop {
+	return func(props *Props) {
+		props.Scrollbar = scrollbar
+	}
+}
+
+// WithWheelDelta sets the number of items scrolled per mouse wheel event.
+func WithWheelDelta(delta int) prop {
+	return func(props *Props) {
+		props.WheelDelta = delta
+	}
+}
+
+func WithOnCursorChange(onCursorChange func(index int)) prop {
+	return func(props *Props) {
+		props.OnCursorChange = onCursorChange
+	}
+}
+
+// WithOnSelectionChange is called with the sorted indices of the selected items.
+// Without multi selection the cursor is the selection.
+func WithOnSelectionChange(onSelectionChange func(selected []int)) prop {
+	return func(props *Props) {
+		props.OnSelectionChange = onSelectionChange
+	}
+}
+
+// WithOnActivate is called when enter is pressed on an item.
+func WithOnActivate(onActivate func(index int)) prop {
+	return func(props *Props) {
+		props.OnActivate = onActivate
+	}
+}
+
+func WithKeyMap(keyMap KeyMap) prop {
+	return func(props *Props) {
+		props.KeyMap = keyMap
+	}
+}
+
+func WithStyles(styles style.ListTheme) prop {
+	return func(props *Props) {
+		props.Styles = styles
+	}
+}
+
+func WithGrowX(grow bool) prop {
+	return func(props *Props) {
+		props.Layout.GrowX = grow
+	}
+}
+
+func WithGrowY(grow bool) prop {
+	return func(props *Props) {
+		props.Layout.GrowY = grow
+	}
+}
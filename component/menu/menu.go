@@ -0,0 +1,580 @@
+package menu
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/style"
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// Item is an entry in a menu.
+type Item struct {
+	// Label is the text of the item. The letter after an & is the accelerator
+	// which chooses the item while the menu is open. Use && for an &.
+	Label string
+	// Shortcut is shown right of the label. The menu does not bind it.
+	Shortcut string
+	Disabled bool
+	// Checkable items show a check mark when Checked is set. OnSelect is
+	// called to toggle it.
+	Checkable bool
+	Checked   bool
+	// Items opens a submenu instead of choosing the item.
+	Items    []Item
+	OnSelect func()
+
+	separator bool
+}
+
+// Separator is a line between groups of items.
+func Separator() Item {
+	return Item{separator: true}
+}
+
+// Menu is a menu in the menubar.
+type Menu struct {
+	// Title is the text in the menubar. The letter after an & opens the menu
+	// with alt and the letter.
+	Title string
+	Items []Item
+}
+
+type mode int
+
+const (
+	modeBar mode = iota
+	modeContext
+)
+
+// Props holds the configuration for the menubar and the context menu. Use
+// NewBar or NewContext to create one of them.
+type Props struct {
+	// Menus are the menus of a menubar.
+	Menus []Menu
+	// Items are the items of a context menu.
+	Items []Item
+	// Child is the component a context menu is opened on.
+	Child  app.FC
+	KeyMap KeyMap
+	Styles style.MenuTheme
+	app.Layout
+
+	mode mode
+}
+
+type prop func(*Props)
+
+type KeyMap struct {
+	// Open opens the first menu of a menubar or the context menu of the
+	// focused child.
+	Open  key.Binding
+	Close key.Binding
+	Up    key.Binding
+	Down  key.Binding
+	// Left and Right close and open submenus and move between the menus of
+	// a menubar.
+	Left   key.Binding
+	Right  key.Binding
+	Choose key.Binding
+}
+
+func (km KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{km.Open, km.Up, km.Down, km.Choose}
+}
+
+func (km KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{km.Open, km.Close, km.Choose},
+		{km.Up, km.Down, km.Left, km.Right},
+	}
+}
+
+func defaultKeyMap(m mode) KeyMap {
+	open := key.NewBinding(
+		key.WithKeys("f10"),
+		key.WithHelp("f10", "open menu"),
+	)
+	if m == modeContext {
+		open = key.NewBinding(
+			key.WithKeys("shift+f10"),
+			key.WithHelp("shift+f10", "open menu"),
+		)
+	}
+	return KeyMap{
+		Open: open,
+		Close: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "close"),
+		),
+		Up: key.NewBinding(
+			key.WithKeys("up"),
+			key.WithHelp("↑", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down"),
+			key.WithHelp("↓", "down"),
+		),
+		Left: key.NewBinding(
+			key.WithKeys("left"),
+			key.WithHelp("←", "back"),
+		),
+		Right: key.NewBinding(
+			key.WithKeys("right"),
+			key.WithHelp("→", "submenu"),
+		),
+		Choose: key.NewBinding(
+			key.WithKeys("enter", "space"),
+			key.WithHelp("enter", "choose"),
+		),
+	}
+}
+
+// menuState is mutated in place by the event handlers which call c.Update().
+type menuState struct {
+	open bool
+	// active is the open menu of a menubar.
+	active int
+	// path is the cursor in each open level, the menu and its submenus. The
+	// keys move the cursor of the last level. -1 is no cursor.
+	path []int
+	// at is where the context menu was opened.
+	at app.Rect
+	// focused is the component which had the focus before the menu was
+	// opened. It gets it back when the menu is closed with the keyboard or
+	// an item is chosen.
+	focused string
+}
+
+// Bar is the functional component for the menubar.
+func Bar(c *app.Ctx, rawProps app.Props) string {
+	props, ok := rawProps.(Props)
+	if !ok {
+		panic("Bar: props must be of type menu.Props")
+	}
+	return menu(c, props)
+}
+
+// Context is the functional component for the context menu of a child.
+func Context(c *app.Ctx, rawProps app.Props) string {
+	props, ok := rawProps.(Props)
+	if !ok {
+		panic("Context: props must be of type menu.Props")
+	}
+	return menu(c, props)
+}
+
+// menu renders a menubar or context menu. The open menus are shown in
+// overlays and have the focus while open.
+func menu(c *app.Ctx, props Props) string {
+	id := app.UseID(c)
+	state, _ := app.UseState(c, &menuState{})
+	width, _ := app.UseSize(c)
+	bounds := app.UseBounds(c)
+
+	bar := props.mode == modeBar
+	path := validPath(props, state)
+	open := state.open && c.UIState.Focused == id && len(path) > 0
+
+	// levelItems returns the items of the menu open at the level.
+	levelItems := func(level int) []Item {
+		items := rootItems(props, state.active)
+		for l := range level {
+			items = items[state.path[l]].Items
+		}
+		return items
+	}
+
+	openMenu := func(active int, cursor int) {
+		if !open {
+			state.focused = c.UIState.Focused
+		}
+		state.open = true
+		state.active = active
+		state.path = []int{cursor}
+		c.UIState.Focused = id
+		c.Update()
+	}
+
+	closeMenu := func(restoreFocus bool) {
+		state.open = false
+		state.path = nil
+		if restoreFocus && c.UIState.Focused == id {
+			c.UIState.Focused = state.focused
+		}
+		c.Update()
+	}
+
+	choose := func(level, index int) {
+		items := levelItems(level)
+		if index < 0 || index >= len(items) || items[index].separator || items[index].Disabled {
+			return
+		}
+		item := items[index]
+		if len(item.Items) > 0 {
+			state.path = append(slices.Clone(state.path[:level]), index, nextEnabled(item.Items, -1, 1))
+			c.Update()
+			return
+		}
+		closeMenu(true)
+		if item.OnSelect != nil {
+			item.OnSelect()
+		}
+	}
+
+	// titleFor returns the menu of the menubar with alt and the key as its
+	// accelerator or -1.
+	titleFor := func(keyMsg tea.KeyMsg) int {
+		k := keyMsg.Key()
+		if !bar || k.Mod != tea.ModAlt {
+			return -1
+		}
+		for i, m := range props.Menus {
+			if r := accelerator(m.Title); r != 0 && r == unicode.ToLower(k.Code) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	app.UseGlobalKeyHandler(c, func(keyMsg tea.KeyMsg) bool {
+		if i := titleFor(keyMsg); i >= 0 {
+			openMenu(i, nextEnabled(props.Menus[i].Items, -1, 1))
+			return true
+		}
+		if open || !key.Matches(keyMsg, props.KeyMap.Open) {
+			return false
+		}
+		if bar {
+			if len(props.Menus) == 0 {
+				return false
+			}
+			openMenu(0, nextEnabled(props.Menus[0].Items, -1, 1))
+			return true
+		}
+		// The context menu opens for the focused component inside the child.
+		if !strings.HasPrefix(c.UIState.Focused, id+"_") || len(props.Items) == 0 {
+			return false
+		}
+		state.at = bounds
+		openMenu(0, nextEnabled(props.Items, -1, 1))
+		return true
+	})
+
+	if open {
+		app.UseKeyHandler(c, func(keyMsg tea.KeyMsg) bool {
+			level := len(state.path) - 1
+			items := levelItems(level)
+			cursor := state.path[level]
+
+			if i := titleFor(keyMsg); i >= 0 {
+				openMenu(i, nextEnabled(props.Menus[i].Items, -1, 1))
+				return true
+			}
+
+			switch {
+			case key.Matches(keyMsg, props.KeyMap.Close):
+				if level > 0 {
+					state.path = state.path[:level]
+					c.Update()
+				} else {
+					closeMenu(true)
+				}
+			case key.Matches(keyMsg, props.KeyMap.Up):
+				state.path[level] = nextEnabled(items, cursor, -1)
+				c.Update()
+			case key.Matches(keyMsg, props.KeyMap.Down):
+				state.path[level] = nextEnabled(items, cursor, 1)
+				c.Update()
+			case key.Matches(keyMsg, props.KeyMap.Right):
+				if cursor >= 0 && len(items[cursor].Items) > 0 {
+					choose(level, cursor)
+				} else if bar {
+					next := (state.active + 1) % len(props.Menus)
+					openMenu(next, nextEnabled(props.Menus[next].Items, -1, 1))
+				}
+			case key.Matches(keyMsg, props.KeyMap.Left):
+				if level > 0 {
+					state.path = state.path[:level]
+					c.Update()
+				} else if bar {
+					prev := (state.active - 1 + len(props.Menus)) % len(props.Menus)
+					openMenu(prev, nextEnabled(props.Menus[prev].Items, -1, 1))
+				}
+			case key.Matches(keyMsg, props.KeyMap.Choose):
+				choose(level, cursor)
+			default:
+				text := keyMsg.Key().Text
+				if text == "" {
+					// Let keys like tab move the focus away and close the menu.
+					return false
+				}
+				choose(level, acceleratorIndex(items, text))
+			}
+			return true
+		})
+	}
+
+	app.UseMouseHandler(c, func(msg tea.MouseMsg, childID string) bool {
+		kind, level, index := parseChildID(childID)
+		switch msg := msg.(type) {
+		case tea.MouseClickMsg:
+			if bar || msg.Button != tea.MouseRight || len(props.Items) == 0 {
+				return false
+			}
+			if open && kind == "item" {
+				return true
+			}
+			mouse := msg.Mouse()
+			state.at = app.Rect{X: mouse.X, Y: mouse.Y}
+			openMenu(0, -1)
+			return true
+		case tea.MouseMotionMsg:
+			if !open {
+				return false
+			}
+			switch kind {
+			case "title":
+				if index != state.active {
+					openMenu(index, -1)
+				}
+				return true
+			case "item":
+				if level >= len(state.path) {
+					return false
+				}
+				// Hovering an item moves the cursor to it and opens its
+				// submenu.
+				path := append(slices.Clone(state.path[:level]), index)
+				if item := levelItems(level)[index]; !item.Disabled && len(item.Items) > 0 {
+					path = append(path, -1)
+				}
+				if !slices.Equal(path, state.path) {
+					state.path = path
+					c.Update()
+				}
+				return true
+			}
+		case tea.MouseReleaseMsg:
+			if msg.Button != tea.MouseLeft {
+				return false
+			}
+			switch kind {
+			case "title":
+				if open && index == state.active {
+					closeMenu(true)
+				} else {
+					openMenu(index, -1)
+				}
+				return true
+			case "item":
+				if open && level < len(state.path) {
+					choose(level, index)
+				}
+				return true
+			}
+		}
+		return false
+	})
+
+	if c.LayoutPhase == app.LayoutPhaseFinalRender {
+		state.open = open
+		if open {
+			state.path = path
+		} else {
+			state.path = nil
+		}
+	}
+
+	var content string
+	var titles []app.Rect
+	if bar {
+		content, titles = renderBar(c, props, open, state.active, width)
+		content = c.MouseZone(content)
+	} else {
+		content = c.MouseZone(props.Child(c).String())
+	}
+
+	if !open {
+		return content
+	}
+
+	for level := range path {
+		anchor := state.at
+		placement := app.Below
+		switch {
+		case level > 0:
+			// A submenu is placed right of the item opening it with its first
+			// item on the same line.
+			item, ok := app.UseChildBounds(c, itemID(level-1, path[level-1]))
+			if !ok {
+				continue
+			}
+			frame := props.Styles.Menu
+			anchor = app.Rect{
+				X:      item.X - frame.GetBorderLeftSize() - frame.GetPaddingLeft(),
+				Y:      item.Y - frame.GetBorderTopSize() - frame.GetPaddingTop(),
+				Width:  item.Width + frame.GetHorizontalFrameSize(),
+				Height: 1,
+			}
+			placement = app.Right
+		case bar:
+			anchor = titles[state.active]
+			anchor.X += bounds.X
+			anchor.Y += bounds.Y
+		}
+		app.UseOverlay(c, app.Overlay{
+			Content:   renderMenu(c, props.Styles, levelItems(level), level, path[level]),
+			Anchor:    anchor,
+			Placement: placement,
+			OnDismiss: func() {
+				closeMenu(false)
+			},
+		})
+	}
+
+	return content
+}
+
+// rootItems returns the items of the menu which is opened first.
+func rootItems(props Props, active int) []Item {
+	if props.mode == modeContext {
+		return props.Items
+	}
+	if active < 0 || active >= len(props.Menus) {
+		return nil
+	}
+	return props.Menus[active].Items
+}
+
+// validPath returns the open levels which are still there after the items
+// have changed.
+func validPath(props Props, state *menuState) []int {
+	items := rootItems(props, state.active)
+	if len(items) == 0 {
+		return nil
+	}
+	path := make([]int, 0, len(state.path))
+	for _, cursor := range state.path {
+		if cursor >= len(items) {
+			cursor = -1
+		}
+		path = append(path, cursor)
+		if cursor < 0 || len(items[cursor].Items) == 0 {
+			break
+		}
+		items = items[cursor].Items
+	}
+	return path
+}
+
+// nextEnabled returns the next item from the index in the direction which can
+// be chosen. It wraps around and returns -1 when there is none.
+func nextEnabled(items []Item, from int, dir int) int {
+	if from < 0 && dir < 0 {
+		from = 0
+	}
+	for i := range items {
+		index := ((from+dir*(i+1))%len(items) + len(items)) % len(items)
+		if !items[index].separator && !items[index].Disabled {
+			return index
+		}
+	}
+	return -1
+}
+
+// acceleratorIndex returns the item with the text as its accelerator or -1.
+func acceleratorIndex(items []Item, text string) int {
+	for i, item := range items {
+		if item.separator || item.Disabled {
+			continue
+		}
+		if r := accelerator(item.Label); r != 0 && string(r) == strings.ToLower(text) {
+			return i
+		}
+	}
+	return -1
+}
+
+func itemID(level, index int) string {
+	return "item:" + strconv.Itoa(level) + ":" + strconv.Itoa(index)
+}
+
+// parseChildID splits the ID of a mouse zone made by titleID or itemID.
+func parseChildID(childID string) (kind string, level int, index int) {
+	parts := strings.Split(childID, ":")
+	switch {
+	case len(parts) == 2 && parts[0] == "title":
+		index, err := strconv.Atoi(parts[1])
+		if err == nil {
+			return "title", 0, index
+		}
+	case len(parts) == 3 && parts[0] == "item":
+		level, err1 := strconv.Atoi(parts[1])
+		index, err2 := strconv.Atoi(parts[2])
+		if err1 == nil && err2 == nil {
+			return "item", level, index
+		}
+	}
+	return "", 0, 0
+}
+
+// NewBar creates a menubar with the menus. A menu is opened with the mouse,
+// alt and its accelerator or f10.
+func NewBar(c *app.Ctx, menus []Menu, opts ...prop) *app.C {
+	p := newProps(c, modeBar)
+	p.Menus = menus
+	p.Layout = app.Layout{
+		GrowX: true,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&p)
+		}
+	}
+	return c.Render(Bar, p)
+}
+
+// NewContext wraps the child with a context menu. It is opened with a right
+// click on the child or shift+f10 while a component in it has the focus.
+func NewContext(c *app.Ctx, child app.FC, items []Item, opts ...prop) *app.C {
+	p := newProps(c, modeContext)
+	p.Child = child
+	p.Items = items
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&p)
+		}
+	}
+	return c.Render(Context, p)
+}
+
+func newProps(c *app.Ctx, m mode) Props {
+	return Props{
+		KeyMap: defaultKeyMap(m),
+		Styles: c.Theme.Menu,
+		mode:   m,
+	}
+}
+
+func WithKeyMap(keyMap KeyMap) prop {
+	return func(p *Props) {
+		p.KeyMap = keyMap
+	}
+}
+func WithStyles(styles style.MenuTheme) prop {
+	return func(p *Props) {
+		p.Styles = styles
+	}
+}
+
+// WithGrowX makes a menubar fill the width. It does by default.
+func WithGrowX(grow bool) prop {
+	return func(p *Props) {
+		p.GrowX = grow
+	}
+}
@@ -0,0 +1,142 @@
+package menu
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/style"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// parseLabel removes the & marker from the label and returns the position of
+// the accelerator in the runes of the text or -1.
+func parseLabel(label string) (string, int) {
+	var text []rune
+	pos := -1
+	runes := []rune(label)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '&' && i+1 < len(runes) {
+			i++
+			if runes[i] != '&' && pos < 0 {
+				pos = len(text)
+			}
+		}
+		text = append(text, runes[i])
+	}
+	return string(text), pos
+}
+
+// accelerator returns the lowercase accelerator of the label or 0.
+func accelerator(label string) rune {
+	text, pos := parseLabel(label)
+	if pos < 0 {
+		return 0
+	}
+	return unicode.ToLower([]rune(text)[pos])
+}
+
+// renderLabel renders the label with its accelerator marked.
+func renderLabel(label string, s lipgloss.Style, accelerator lipgloss.Style) string {
+	text, pos := parseLabel(label)
+	if pos < 0 {
+		return s.Render(text)
+	}
+	runes := []rune(text)
+	return s.Render(string(runes[:pos])) +
+		accelerator.Inherit(s).Render(string(runes[pos])) +
+		s.Render(string(runes[pos+1:]))
+}
+
+// renderBar renders the titles of the menus on one line. It returns where
+// each title is in the line.
+func renderBar(c *app.Ctx, props Props, open bool, active int, width int) (string, []app.Rect) {
+	styles := props.Styles
+	var line strings.Builder
+	titles := make([]app.Rect, len(props.Menus))
+	x := 0
+	for i, m := range props.Menus {
+		s := styles.Title
+		if open && i == active {
+			s = styles.TitleActive
+		}
+		s = s.Inherit(styles.Bar)
+		text, _ := parseLabel(m.Title)
+		titleWidth := ansi.StringWidth(text) + 2
+		line.WriteString(c.MouseZoneChild("title:"+strconv.Itoa(i), s.Render(" ")+renderLabel(m.Title, s, styles.Accelerator)+s.Render(" ")))
+		titles[i] = app.Rect{X: x, Width: titleWidth, Height: 1}
+		x += titleWidth
+	}
+	if !props.GrowX {
+		width = x
+	}
+	if x < width {
+		line.WriteString(styles.Bar.Render(strings.Repeat(" ", width-x)))
+	}
+	return line.String(), titles
+}
+
+// renderMenu renders the box with the items of a menu. Every column is only
+// there when one of the items needs it.
+func renderMenu(c *app.Ctx, styles style.MenuTheme, items []Item, level int, cursor int) string {
+	checkable, submenus := false, false
+	labelWidth, shortcutWidth := 0, 0
+	for _, item := range items {
+		text, _ := parseLabel(item.Label)
+		labelWidth = max(labelWidth, ansi.StringWidth(text))
+		shortcutWidth = max(shortcutWidth, ansi.StringWidth(item.Shortcut))
+		checkable = checkable || item.Checkable
+		submenus = submenus || len(item.Items) > 0
+	}
+
+	checkWidth, arrowWidth := 0, 0
+	if checkable {
+		checkWidth = 2
+	}
+	if submenus {
+		arrowWidth = 2
+	}
+	if shortcutWidth > 0 {
+		shortcutWidth += 2
+	}
+	innerWidth := 1 + checkWidth + labelWidth + shortcutWidth + arrowWidth + 1
+
+	menuBg := lipgloss.NewStyle().Background(styles.Menu.GetBackground())
+	lines := make([]string, len(items))
+	for i, item := range items {
+		if item.separator {
+			lines[i] = styles.Separator.Inherit(menuBg).Render(strings.Repeat("─", innerWidth))
+			continue
+		}
+
+		s := styles.Item
+		switch {
+		case item.Disabled:
+			s = styles.ItemDisabled
+		case i == cursor:
+			s = styles.ItemCursor
+		}
+		s = s.Inherit(menuBg)
+
+		check := strings.Repeat(" ", checkWidth)
+		if item.Checkable && item.Checked {
+			check = "✓ "
+		}
+		arrow := strings.Repeat(" ", arrowWidth)
+		if submenus && len(item.Items) > 0 {
+			arrow = " ▸"
+		}
+		text, _ := parseLabel(item.Label)
+		gap := innerWidth - 2 - checkWidth - ansi.StringWidth(text) - ansi.StringWidth(item.Shortcut) - arrowWidth
+
+		line := s.Render(" "+check) +
+			renderLabel(item.Label, s, styles.Accelerator) +
+			s.Render(strings.Repeat(" ", gap)) +
+			styles.Shortcut.Inherit(s).Render(item.Shortcut) +
+			s.Render(arrow+" ")
+		lines[i] = c.MouseZoneChild(itemID(level, i), line)
+	}
+	return styles.Menu.Render(strings.Join(lines, "\n"))
+}
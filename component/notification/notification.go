@@ -0,0 +1,308 @@
+package notification
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/component/context"
+	"github.com/alexanderbh/bubbleapp/style"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// Toast is a message shown for a while in a corner of the screen.
+type Toast struct {
+	Title   string
+	Message string
+	Variant style.Variant
+	// Duration is how long the toast is shown. Zero uses the duration of the
+	// provider and a negative duration keeps it until it is clicked.
+	Duration time.Duration
+}
+
+type toast struct {
+	Toast
+	id      int
+	expires time.Time
+}
+
+// Controller holds the toasts shown by a provider. It is safe to use from
+// other goroutines.
+type Controller struct {
+	mu     sync.Mutex
+	toasts []toast
+	nextID int
+	c      *app.Ctx
+
+	duration time.Duration
+	max      int
+}
+
+// Show shows the toast and returns its id.
+func (n *Controller) Show(t Toast) int {
+	if n == nil {
+		return 0
+	}
+	n.mu.Lock()
+	n.nextID++
+	shown := toast{Toast: t, id: n.nextID}
+	if t.Duration == 0 {
+		shown.Duration = n.duration
+	}
+	if shown.Duration > 0 {
+		shown.expires = time.Now().Add(shown.Duration)
+	}
+	n.toasts = append(n.toasts, shown)
+	if len(n.toasts) > n.max {
+		n.toasts = n.toasts[len(n.toasts)-n.max:]
+	}
+	n.mu.Unlock()
+	n.c.Update()
+	return shown.id
+}
+
+func (n *Controller) Success(message string) int {
+	return n.Show(Toast{Message: message, Variant: style.Success})
+}
+func (n *Controller) Danger(message string) int {
+	return n.Show(Toast{Message: message, Variant: style.Danger})
+}
+func (n *Controller) Info(message string) int {
+	return n.Show(Toast{Message: message, Variant: style.Info})
+}
+func (n *Controller) Warning(message string) int {
+	return n.Show(Toast{Message: message, Variant: style.Warning})
+}
+
+// Dismiss removes the toast with the id.
+func (n *Controller) Dismiss(id int) {
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	for i, t := range n.toasts {
+		if t.id == id {
+			n.toasts = append(n.toasts[:i:i], n.toasts[i+1:]...)
+			break
+		}
+	}
+	n.mu.Unlock()
+	n.c.Update()
+}
+
+// Clear removes all toasts.
+func (n *Controller) Clear() {
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	n.toasts = nil
+	n.mu.Unlock()
+	n.c.Update()
+}
+
+// expire removes the toasts whose time is up and reports whether any were
+// removed.
+func (n *Controller) expire(now time.Time) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	kept := n.toasts[:0]
+	for _, t := range n.toasts {
+		if t.expires.IsZero() || now.Before(t.expires) {
+			kept = append(kept, t)
+		}
+	}
+	removed := len(kept) != len(n.toasts)
+	n.toasts = kept
+	return removed
+}
+
+// expiring reports whether any toast is dismissed when its time is up.
+func (n *Controller) expiring() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, t := range n.toasts {
+		if !t.expires.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *Controller) shown() []toast {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]toast(nil), n.toasts...)
+}
+
+// Context holds the controller of the nearest provider.
+var Context = context.Create[*Controller](nil)
+
+// current is the controller of the provider rendered last. It is used by
+// Notify.
+var current atomic.Pointer[Controller]
+
+// UseToast returns the controller of the nearest provider. Without a
+// provider the toasts are ignored.
+func UseToast(c *app.Ctx) *Controller {
+	return context.UseContext(c, Context)
+}
+
+// Notify shows a toast from anywhere, like a goroutine without a Ctx, using
+// the provider rendered last.
+func Notify(message string, variant style.Variant) int {
+	return current.Load().Show(Toast{Message: message, Variant: variant})
+}
+
+type Props struct {
+	Child app.FC
+	// Corner is where the toasts are stacked.
+	Corner   app.Corner
+	Duration time.Duration
+	// Max is the number of toasts shown at once. The oldest are removed
+	// first.
+	Max   int
+	Width int
+	app.Layout
+}
+
+type prop func(*Props)
+
+// checkInterval is how often the toasts are checked for having expired.
+const checkInterval = 100 * time.Millisecond
+
+// Provider makes a controller available to the components below it and
+// shows its toasts on top of them. Clicking a toast dismisses it.
+func Provider(c *app.Ctx, rawProps app.Props) string {
+	props, ok := rawProps.(Props)
+	if !ok {
+		panic("Provider: props must be of type notification.Props")
+	}
+
+	controller, _ := app.UseState(c, &Controller{})
+	controller.mu.Lock()
+	controller.c, controller.duration, controller.max = c, props.Duration, max(1, props.Max)
+	controller.mu.Unlock()
+	current.Store(controller)
+
+	// The toasts are checked for having expired only while one of them will.
+	var expire func()
+	if controller.expiring() {
+		expire = func() {
+			if controller.expire(time.Now()) {
+				c.Update()
+			}
+		}
+	}
+	app.UseTick(c, checkInterval, expire)
+
+	app.UseMouseHandler(c, func(msg tea.MouseMsg, childID string) bool {
+		release, ok := msg.(tea.MouseReleaseMsg)
+		if !ok || release.Button != tea.MouseLeft {
+			return false
+		}
+		id, err := strconv.Atoi(strings.TrimPrefix(childID, "toast-"))
+		if err != nil {
+			return false
+		}
+		controller.Dismiss(id)
+		return true
+	})
+
+	content := context.NewProvider(c, Context, controller, props.Child).String()
+
+	toasts := controller.shown()
+	if len(toasts) == 0 {
+		return content
+	}
+
+	// Toasts are stacked from the corner so the newest is closest to it.
+	top := props.Corner == app.TopLeft || props.Corner == app.TopRight
+	boxes := make([]string, len(toasts))
+	for i, t := range toasts {
+		box := c.MouseZoneChild(fmt.Sprintf("toast-%d", t.id), renderToast(c, t, props.Width))
+		if top {
+			boxes[len(toasts)-1-i] = box
+		} else {
+			boxes[i] = box
+		}
+	}
+	app.UseOverlay(c, app.Overlay{
+		Content: lipgloss.JoinVertical(lipgloss.Left, boxes...),
+		Corner:  props.Corner,
+	})
+
+	return content
+}
+
+var icons = map[style.Variant]string{
+	style.Success: "✓",
+	style.Danger:  "✗",
+	style.Warning: "!",
+	style.Info:    "i",
+}
+
+func renderToast(c *app.Ctx, t toast, width int) string {
+	accent := c.Theme.Text[t.Variant][style.Normal]
+
+	box := c.Theme.Toast.BorderForeground(accent.GetForeground()).Width(width)
+
+	text := t.Message
+	if t.Title != "" {
+		text = lipgloss.NewStyle().Bold(true).Render(t.Title) + "\n" + text
+	}
+	if icon, ok := icons[t.Variant]; ok {
+		text = accent.Bold(true).Render(icon) + " " + text
+	}
+	return box.Render(text)
+}
+
+// NewProvider creates a provider showing toasts on top of the child.
+func NewProvider(c *app.Ctx, child app.FC, opts ...prop) *app.C {
+	p := Props{
+		Child:    child,
+		Corner:   app.BottomRight,
+		Duration: 4 * time.Second,
+		Max:      5,
+		Width:    36,
+		Layout: app.Layout{
+			GrowX: true,
+			GrowY: true,
+		},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&p)
+		}
+	}
+	return c.Render(Provider, p)
+}
+
+// WithCorner sets the corner of the screen the toasts are stacked in.
+func WithCorner(corner app.Corner) prop {
+	return func(p *Props) {
+		p.Corner = corner
+	}
+}
+
+// WithDuration sets how long toasts without their own duration are shown.
+func WithDuration(duration time.Duration) prop {
+	return func(p *Props) {
+		p.Duration = duration
+	}
+}
+func WithMax(count int) prop {
+	return func(p *Props) {
+		p.Max = count
+	}
+}
+func WithWidth(width int) prop {
+	return func(p *Props) {
+		p.Width = width
+	}
+}
@@ -0,0 +1,116 @@
+package progress
+
+import (
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// brailleDots are the bits of the dots in a braille cell by column and row.
+var brailleDots = [2][4]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+// gaugeStart and gaugeSweep are where the ring starts, in degrees clockwise
+// from the top, and how far it goes. The gap is at the bottom.
+const (
+	gaugeStart = 225.0
+	gaugeSweep = 270.0
+)
+
+// renderGauge draws the segments along a ring of braille dots. The label is
+// put in the middle of the ring or to the right of it when the ring is too
+// small.
+func renderGauge(props Props, values []float64, width int, height int, label string) string {
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+	// A braille dot is about as wide as it is high, so the ring fits in the
+	// smaller of the width and the height counted in dots.
+	size := float64(min(width*2, height*4))
+	cx, cy := float64(width), float64(height*2)
+	outer := size/2 - 0.5
+	inner := outer - max(1.5, outer*0.3)
+
+	ends := make([]float64, len(values))
+	sum := 0.0
+	for i, v := range values {
+		sum += v
+		ends[i] = clamp(sum)
+	}
+	// segmentAt returns the segment covering the dot, -1 for the track and -2
+	// for dots not on the ring.
+	segmentAt := func(x, y int) int {
+		dx, dy := float64(x)+0.5-cx, float64(y)+0.5-cy
+		if d := math.Hypot(dx, dy); d > outer || d < inner {
+			return -2
+		}
+		angle := math.Atan2(dx, -dy) * 180 / math.Pi
+		at := math.Mod(angle-gaugeStart+720, 360) / gaugeSweep
+		if at > 1 {
+			return -2
+		}
+		for i, end := range ends {
+			if at < end {
+				return i
+			}
+		}
+		return -1
+	}
+
+	track := lipgloss.NewStyle().Foreground(props.Styles.Track.GetBackground())
+	lines := make([]string, height)
+	for row := range height {
+		var b strings.Builder
+		for col := range width {
+			// A cell has one color so the dots of the first segment in it
+			// are drawn and the rest are left out.
+			segment, dots := -2, rune(0)
+			for dx := range 2 {
+				for dy := range 4 {
+					s := segmentAt(col*2+dx, row*4+dy)
+					if s == -2 {
+						continue
+					}
+					if segment == -2 || (s >= 0 && (segment < 0 || s < segment)) {
+						segment, dots = s, 0
+					}
+					if s == segment {
+						dots |= brailleDots[dx][dy]
+					}
+				}
+			}
+			switch {
+			case segment == -2:
+				b.WriteString(" ")
+			case segment == -1:
+				b.WriteString(track.Render(string(0x2800 + dots)))
+			default:
+				b.WriteString(props.Styles.Fill[props.Segments[segment].Variant].Render(string(0x2800 + dots)))
+			}
+		}
+		lines[row] = b.String()
+	}
+
+	if label == "" {
+		return strings.Join(lines, "\n")
+	}
+	labelWidth := lipgloss.Width(label)
+	middle := height / 2
+	if labelWidth+2 <= int(inner) && height >= 3 {
+		x := (width - labelWidth) / 2
+		lines[middle] = ansi.Cut(lines[middle], 0, x) + label + ansi.Cut(lines[middle], x+labelWidth, width)
+	} else {
+		for i := range lines {
+			pad := strings.Repeat(" ", labelWidth+1)
+			if i == middle {
+				pad = " " + label
+			}
+			lines[i] += pad
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,295 @@
+package progress
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/style"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// Segment is a part of a stacked bar. Value is the share of the whole bar
+// from 0 to 1.
+type Segment struct {
+	Value   float64
+	Variant style.Variant
+}
+
+type mode int
+
+const (
+	modeBar mode = iota
+	modeStacked
+	modeGauge
+)
+
+type Props struct {
+	mode        mode
+	Segments    []Segment
+	ShowPercent bool
+	// ShowETA estimates the time left from how fast the progress has moved
+	// since it was first shown.
+	ShowETA bool
+	// Animate eases the bar towards a new value instead of jumping to it.
+	Animate bool
+	Styles  style.ProgressTheme
+	app.Layout
+}
+
+type prop func(*Props)
+
+// animationDuration is how long an animated bar takes to a new value.
+const animationDuration = 400 * time.Millisecond
+
+type progressState struct {
+	// shown are the values currently drawn which trail the segments while
+	// animating from the values in from.
+	shown []float64
+	from  []float64
+	key   string
+	// started and startValue are when and at what value the ETA is measured
+	// from.
+	started    time.Time
+	startValue float64
+}
+
+// Progress shows how far something has come as a bar, a stacked bar or a
+// round gauge.
+func Progress(c *app.Ctx, rawProps app.Props) string {
+	props, ok := rawProps.(Props)
+	if !ok {
+		panic("Progress: props must be of type progress.Props")
+	}
+
+	state, _ := app.UseState(c, &progressState{})
+
+	values := make([]float64, len(props.Segments))
+	total := 0.0
+	for i, s := range props.Segments {
+		values[i] = clamp(s.Value)
+		total += values[i]
+	}
+	total = clamp(total)
+
+	// The animation starts again from the drawn values when the values
+	// change. It only ticks while the bar moves.
+	key := fmt.Sprint(values)
+	duration := animationDuration
+	if !props.Animate {
+		duration = 0
+	}
+	progress := app.UseTransition(c, key, duration)
+	if len(state.shown) != len(values) || !props.Animate {
+		state.shown, state.from, state.key = values, values, key
+	} else if state.key != key {
+		state.from, state.key = state.shown, key
+	}
+	shown := make([]float64, len(values))
+	for i, v := range values {
+		shown[i] = state.from[i] + (v-state.from[i])*app.EaseOut(progress)
+	}
+	state.shown = shown
+
+	width, _ := app.UseSize(c)
+	if !props.GrowX && props.Width > 0 {
+		width = props.Width
+	}
+
+	var labels []string
+	if props.ShowPercent {
+		labels = append(labels, fmt.Sprintf("%3.0f%%", total*100))
+	}
+	if props.ShowETA {
+		labels = append(labels, eta(state, total))
+	}
+	label := props.Styles.Label.Render(strings.Join(labels, " "))
+
+	var content string
+	if props.mode == modeGauge {
+		height := max(1, width/2)
+		if props.Height > 0 {
+			height = props.Height
+		}
+		content = renderGauge(props, shown, width, height, label)
+	} else {
+		barWidth := width
+		if len(labels) > 0 {
+			barWidth -= lipgloss.Width(label) + 1
+			label = " " + label
+		}
+		content = renderBar(props, shown, max(0, barWidth)) + label
+	}
+
+	return content
+}
+
+func clamp(v float64) float64 {
+	return max(0, min(v, 1))
+}
+
+// eta returns the estimated time left. It is measured again when the
+// progress goes backwards.
+func eta(state *progressState, value float64) string {
+	now := time.Now()
+	if state.started.IsZero() || value < state.startValue {
+		state.started, state.startValue = now, value
+	}
+	if value >= 1 {
+		return "done"
+	}
+	done := value - state.startValue
+	if done <= 0 {
+		return "ETA --"
+	}
+	left := time.Duration(float64(now.Sub(state.started)) * (1 - value) / done)
+	return "ETA " + left.Round(time.Second).String()
+}
+
+// eighths are the blocks filling one to seven eighths of a cell.
+var eighths = []rune{' ', '▏', '▎', '▍', '▌', '▋', '▊', '▉'}
+
+// renderBar draws the segments with eighths of a cell so small changes show.
+func renderBar(props Props, values []float64, width int) string {
+	// ends are where each segment ends in eighths of a cell.
+	ends := make([]int, len(values))
+	sum := 0.0
+	for i, v := range values {
+		sum += v
+		ends[i] = int(math.Round(clamp(sum) * float64(width*8)))
+	}
+	segmentAt := func(eighth int) int {
+		for i, end := range ends {
+			if eighth < end {
+				return i
+			}
+		}
+		return -1
+	}
+	colorOf := func(segment int) color.Color {
+		if segment < 0 {
+			return props.Styles.Track.GetBackground()
+		}
+		return props.Styles.Fill[props.Segments[segment].Variant].GetForeground()
+	}
+
+	var b strings.Builder
+	run, runStyle := []rune{}, lipgloss.NewStyle()
+	write := func(r rune, s lipgloss.Style) {
+		if len(run) > 0 && s.String() != runStyle.String() {
+			b.WriteString(runStyle.Render(string(run)))
+			run = run[:0]
+		}
+		run, runStyle = append(run, r), s
+	}
+	for x := range width {
+		left := segmentAt(x * 8)
+		n := 1
+		for n < 8 && segmentAt(x*8+n) == left {
+			n++
+		}
+		switch {
+		case n == 8 && left < 0:
+			write(' ', props.Styles.Track)
+		case n == 8:
+			write('█', props.Styles.Fill[props.Segments[left].Variant])
+		default:
+			right := segmentAt(x*8 + n)
+			write(eighths[n], lipgloss.NewStyle().Foreground(colorOf(left)).Background(colorOf(right)))
+		}
+	}
+	if len(run) > 0 {
+		b.WriteString(runStyle.Render(string(run)))
+	}
+	return b.String()
+}
+
+func newProps(c *app.Ctx, m mode, segments []Segment, opts []prop) Props {
+	p := Props{
+		mode:        m,
+		Segments:    segments,
+		ShowPercent: true,
+		Styles:      c.Theme.Progress,
+		Layout: app.Layout{
+			GrowX: true,
+		},
+	}
+	if m == modeGauge {
+		p.Layout = app.Layout{Width: 12}
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&p)
+		}
+	}
+	return p
+}
+
+// New creates a progress bar filled to value which goes from 0 to 1.
+func New(c *app.Ctx, value float64, opts ...prop) *app.C {
+	return c.Render(Progress, newProps(c, modeBar, []Segment{{Value: value}}, opts))
+}
+
+// NewStacked creates a progress bar made of segments following each other.
+func NewStacked(c *app.Ctx, segments []Segment, opts ...prop) *app.C {
+	return c.Render(Progress, newProps(c, modeStacked, segments, opts))
+}
+
+// NewGauge creates a round gauge filled to value which goes from 0 to 1. It
+// is drawn with braille dots and is half as high as it is wide.
+func NewGauge(c *app.Ctx, value float64, opts ...prop) *app.C {
+	return c.Render(Progress, newProps(c, modeGauge, []Segment{{Value: value}}, opts))
+}
+
+// WithVariant sets the color of a bar or gauge with a single value.
+func WithVariant(variant style.Variant) prop {
+	return func(p *Props) {
+		if p.mode != modeStacked && len(p.Segments) == 1 {
+			p.Segments[0].Variant = variant
+		}
+	}
+}
+func WithShowPercent(show bool) prop {
+	return func(p *Props) {
+		p.ShowPercent = show
+	}
+}
+func WithETA(show bool) prop {
+	return func(p *Props) {
+		p.ShowETA = show
+	}
+}
+func WithAnimate(animate bool) prop {
+	return func(p *Props) {
+		p.Animate = animate
+	}
+}
+func WithStyles(styles style.ProgressTheme) prop {
+	return func(p *Props) {
+		p.Styles = styles
+	}
+}
+
+// WithWidth gives the component a fixed width instead of growing.
+func WithWidth(width int) prop {
+	return func(p *Props) {
+		p.Width = width
+		p.GrowX = false
+	}
+}
+
+// WithHeight sets the height of a gauge. The gauge is made as large as fits
+// in the width and the height.
+func WithHeight(height int) prop {
+	return func(p *Props) {
+		p.Height = height
+	}
+}
+func WithGrowX(grow bool) prop {
+	return func(p *Props) {
+		p.GrowX = grow
+	}
+}
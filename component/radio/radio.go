@@ -0,0 +1,143 @@
+package radio
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/style"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+type Props struct {
+	Options []string
+	// Selected is the index of the chosen option or -1.
+	Selected int
+	Disabled bool
+	Variant  style.Variant
+	OnChange func(index int)
+	app.Layout
+}
+
+type prop func(*Props)
+
+// Radio is a group of options where only one can be chosen. The arrows move
+// the choice and space or a click chooses an option.
+func Radio(c *app.Ctx, rawProps app.Props) string {
+	props, ok := rawProps.(Props)
+	if !ok {
+		panic("Radio: props must be of type radio.Props")
+	}
+
+	id := app.UseID(c)
+	// A disabled control can not be focused.
+	focused := !props.Disabled && app.UseIsFocused(c)
+	_, childHoverID := app.UseIsHovered(c)
+
+	choose := func(index int) {
+		if index < 0 || index >= len(props.Options) {
+			return
+		}
+		c.FocusThis(id)
+		if index != props.Selected && props.OnChange != nil {
+			props.OnChange(index)
+		}
+	}
+
+	prev, next := "up", "down"
+	if props.Layout.Direction == app.Horizontal {
+		prev, next = "left", "right"
+	}
+
+	if !props.Disabled {
+		app.UseAction(c, func(childID string) {
+			if index, found := strings.CutPrefix(childID, "option:"); found {
+				i, _ := strconv.Atoi(index)
+				choose(i)
+			}
+		})
+		app.UseKeyHandler(c, func(keyMsg tea.KeyMsg) bool {
+			switch keyMsg.String() {
+			case prev:
+				if props.Selected <= 0 {
+					return false
+				}
+				choose(props.Selected - 1)
+			case next:
+				if props.Selected >= len(props.Options)-1 {
+					return false
+				}
+				choose(props.Selected + 1)
+			case "space":
+				choose(max(props.Selected, 0))
+			default:
+				return false
+			}
+			return true
+		})
+	}
+
+	options := make([]string, len(props.Options))
+	for i, option := range props.Options {
+		childID := "option:" + strconv.Itoa(i)
+
+		// The focus is shown on the chosen option or the first when none is.
+		state := style.Normal
+		if props.Disabled {
+			state = style.Disabled
+		} else if childHoverID == childID {
+			state = style.Hover
+		} else if focused && (i == props.Selected || props.Selected < 0 && i == 0) {
+			state = style.Focus
+		}
+
+		mark := "( )"
+		if i == props.Selected {
+			mark = "(•)"
+		}
+		options[i] = c.MouseZoneChild(childID,
+			c.Theme.Checkbox[props.Variant][state].Render(mark)+" "+c.Theme.Text[style.Base][state].Render(option))
+	}
+
+	if props.Layout.Direction == app.Horizontal {
+		return c.MouseZone(strings.Join(options, "  "))
+	}
+	return c.MouseZone(lipgloss.JoinVertical(lipgloss.Left, options...))
+}
+
+// New creates a radio group. onChange is called with the index of the chosen
+// option. Selected is -1 when no option is chosen.
+func New(c *app.Ctx, options []string, selected int, onChange func(index int), opts ...prop) *app.C {
+	p := Props{
+		Options:  options,
+		Selected: selected,
+		OnChange: onChange,
+		Variant:  style.Primary,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&p)
+		}
+	}
+	return c.Render(Radio, p)
+}
+
+func WithVariant(variant style.Variant) prop {
+	return func(p *Props) {
+		p.Variant = variant
+	}
+}
+
+func WithDisabled(disabled bool) prop {
+	return func(p *Props) {
+		p.Disabled = disabled
+	}
+}
+
+// WithDirection places the options next to each other when horizontal.
+func WithDirection(direction app.LayoutDirection) prop {
+	return func(p *Props) {
+		p.Layout.Direction = direction
+	}
+}
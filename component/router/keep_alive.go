@@ -0,0 +1,90 @@
+package router
+
+import (
+	"slices"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/component/context"
+)
+
+// keptRoute is a rendered route with KeepAlive.
+type keptRoute struct {
+	id     string
+	active bool
+
+	onActivated   []func()
+	onDeactivated []func()
+}
+
+// keptRouteContext holds the kept alive route a component is in.
+var keptRouteContext = context.Create[*keptRoute](nil)
+
+// keep returns the kept alive route with the id and keeps its components
+// while it is hidden. In the final render it is marked as shown.
+func (rc *RouterController) keep(c *app.Ctx, id string) *keptRoute {
+	i := slices.IndexFunc(rc.kept, func(k *keptRoute) bool { return k.id == id })
+	kept := &keptRoute{id: id}
+	if i >= 0 {
+		kept = rc.kept[i]
+		rc.kept = slices.Delete(rc.kept, i, i+1)
+	}
+	// The most recently shown route goes first.
+	rc.kept = slices.Insert(rc.kept, 0, kept)
+
+	if c.LayoutPhase == app.LayoutPhaseFinalRender {
+		kept.onActivated, kept.onDeactivated = nil, nil
+		rc.shown = append(rc.shown, kept)
+		c.KeepAlive(id)
+	}
+	return kept
+}
+
+// updateKept calls the activated and deactivated handlers of the kept alive
+// routes which were shown or hidden by this render. Hidden routes over the
+// max are removed, the least recently shown first.
+func (rc *RouterController) updateKept(c *app.Ctx, max int) {
+	hidden := 0
+	rc.kept = slices.DeleteFunc(rc.kept, func(kept *keptRoute) bool {
+		shown := slices.Contains(rc.shown, kept)
+		switch {
+		case shown && !kept.active:
+			kept.active = true
+			for _, fn := range kept.onActivated {
+				fn()
+			}
+		case !shown && kept.active:
+			kept.active = false
+			for _, fn := range kept.onDeactivated {
+				fn()
+			}
+		}
+		if shown {
+			return false
+		}
+		hidden++
+		if hidden <= max {
+			return false
+		}
+		c.ReleaseKeepAlive(kept.id)
+		return true
+	})
+	rc.shown = nil
+}
+
+// UseActivated calls the function when the kept alive route the component is
+// in is shown, both the first time and when it is shown again.
+func UseActivated(c *app.Ctx, fn func()) {
+	kept := context.UseContext(c, keptRouteContext)
+	if kept != nil && c.LayoutPhase == app.LayoutPhaseFinalRender {
+		kept.onActivated = append(kept.onActivated, fn)
+	}
+}
+
+// UseDeactivated calls the function when the kept alive route the component
+// is in is hidden by navigating to another route.
+func UseDeactivated(c *app.Ctx, fn func()) {
+	kept := context.UseContext(c, keptRouteContext)
+	if kept != nil && c.LayoutPhase == app.LayoutPhaseFinalRender {
+		kept.onDeactivated = append(kept.onDeactivated, fn)
+	}
+}
@@ -0,0 +1,78 @@
+package router
+
+import (
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/style"
+)
+
+// LinkProps defines the properties for the Link component.
+type LinkProps struct {
+	Text    string
+	To      string
+	Variant style.Variant
+	// Options are used when navigating, like WithReplace or WithState.
+	Options []NavigateOption
+	app.Layout
+}
+
+type LinkOption func(*LinkProps)
+
+// Link is a focusable label which navigates to its path when clicked or
+// when enter is pressed. It is bold while its path is the current one.
+func Link(c *app.Ctx, rawProps app.Props) string {
+	props, ok := rawProps.(LinkProps)
+	if !ok {
+		panic("Link: props must be of type router.LinkProps")
+	}
+
+	id := app.UseID(c)
+	focused := app.UseIsFocused(c)
+	hovered, _ := app.UseIsHovered(c)
+	routerCtrl := UseRouterController(c)
+
+	app.UseAction(c, func(_ string) {
+		c.FocusThis(id)
+		routerCtrl.Navigate(c, props.To, props.Options...)
+	})
+
+	state := style.Normal
+	if hovered {
+		state = style.Hover
+	} else if focused {
+		state = style.Focus
+	}
+	s := c.Theme.Text[props.Variant][state]
+	if cleanPath(props.To) == routerCtrl.Current() {
+		s = s.Bold(true)
+	}
+
+	return c.MouseZone(s.Render(props.Text))
+}
+
+// NewLink creates a link to the path.
+func NewLink(c *app.Ctx, text string, to string, opts ...LinkOption) *app.C {
+	p := LinkProps{
+		Text:    text,
+		To:      to,
+		Variant: style.Primary,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&p)
+		}
+	}
+	return c.Render(Link, p)
+}
+
+func WithLinkVariant(variant style.Variant) LinkOption {
+	return func(p *LinkProps) {
+		p.Variant = variant
+	}
+}
+
+// WithNavigateOptions sets the options used when the link navigates.
+func WithNavigateOptions(opts ...NavigateOption) LinkOption {
+	return func(p *LinkProps) {
+		p.Options = opts
+	}
+}
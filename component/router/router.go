@@ -2,20 +2,48 @@ package router
 
 import (
 	"log"
+	"maps"
+	"net/url"
 	"path"
+	"slices"
 	"strings"
 
 	"github.com/alexanderbh/bubbleapp/app"
 	"github.com/alexanderbh/bubbleapp/component/context"
 	"github.com/alexanderbh/bubbleapp/component/text"
+	"github.com/alexanderbh/bubbleapp/component/transition"
 	"github.com/alexanderbh/bubbleapp/style"
 )
 
 // Route defines the structure for a single route.
+// A segment of the path is a literal, a ":param", an optional segment ending
+// in "?" (e.g., ":id?") or a "*" splat as the last segment matching the rest
+// of the path. The splat is in the params as "*" or as its name ("*rest").
+// Routes are tried in order so catch-all routes go last.
 type Route struct {
 	Path      string  // Path segment (e.g., "users", ":id")
 	Component app.FC  // Component to render for this route
 	Children  []Route // Nested routes
+
+	// BeforeEnter is called once when the router starts at or navigates to
+	// a path matching the route. It returns a path to redirect to instead,
+	// or "" to enter the route.
+	BeforeEnter func(to Location) string
+	// BeforeLeave is called before navigating to a path which does not match
+	// the route. Returning false keeps the current path.
+	BeforeLeave func(from, to Location) bool
+
+	// KeepAlive keeps the state of the route's components while another
+	// route is shown, like the scroll position and cursor of a list.
+	KeepAlive bool
+}
+
+// Location is a path the router is at or navigates to.
+type Location struct {
+	URL    string // The path with the query as it was navigated to
+	Path   string
+	Query  url.Values
+	Params map[string]string // The params of the route and its parents
 }
 
 // RouterProps defines the properties for the NewRouter component.
@@ -23,83 +51,296 @@ type RouterProps struct {
 	Routes      []Route
 	InitialPath string
 	NotFound    app.FC // Component to render if no route matches
+	// KeepAliveMax is how many hidden KeepAlive routes are kept. The least
+	// recently shown are removed first. Defaults to 10.
+	KeepAliveMax int
+	// Transition animates the routes and outlets when the matched route
+	// changes. Nil switches right away.
+	Transition *transition.Effect
 }
 
 // --- RouterController ---
 
+// HistoryEntry is a path the router has been at.
+type HistoryEntry struct {
+	Path  string
+	State any // The state navigated with, see UseNavigationState
+}
+
 // RouterController manages routing state and navigation.
 type RouterController struct {
-	History     []string
-	Routes      []Route
-	currentPath string
-	notFound    app.FC
+	// History holds the visited paths. Back and Forward move through it and
+	// navigating to a new path drops the entries after the current one.
+	History    []HistoryEntry
+	Routes     []Route
+	index      int
+	notFound   app.FC
+	transition *transition.Effect
+
+	listeners []*navigationListener
+
+	// kept holds the KeepAlive routes, the most recently shown first.
+	kept  []*keptRoute
+	shown []*keptRoute
 }
 
-// NewRouterController creates and initializes a new RouterController.
+// NewRouterController creates and initializes a new RouterController. The
+// enter guards of the routes matching the initial path are run.
 func NewRouterController(initialPath string, routes []Route, notFound app.FC) *RouterController {
 	rc := &RouterController{
-		History:     make([]string, 0),
-		Routes:      routes,
-		currentPath: "/",
-		notFound:    notFound,
+		Routes:   routes,
+		notFound: notFound,
 	}
-	if initialPath != "" {
-		rc.currentPath = initialPath
+	if initialPath == "" {
+		initialPath = "/"
 	}
-	rc.History = append(rc.History, rc.currentPath)
+	rc.History = []HistoryEntry{{Path: initialPath}}
+	rc.redirect()
 	return rc
 }
 
 // Push navigates to a new path and adds it to history.
 func (rc *RouterController) Push(c *app.Ctx, newPath string) {
-	cleanedPath := path.Clean(newPath)
-	if rc.currentPath == cleanedPath {
-		return
-	}
-	rc.currentPath = cleanedPath
-	rc.History = append(rc.History, rc.currentPath)
-	c.Update()
+	rc.navigate(c, newPath, NavigateOptions{})
 }
 
-// Pop navigates to the previous path in history.
+// Pop is the same as Back. The entry is kept so Forward can return to it.
 func (rc *RouterController) Pop(c *app.Ctx) {
-	if len(rc.History) <= 1 { // Can't pop the last/initial entry
-		return
+	rc.Back(c)
+}
+
+// Back navigates to the previous path in history.
+func (rc *RouterController) Back(c *app.Ctx) {
+	if rc.CanBack() {
+		rc.move(c, rc.index-1, NavigationBack)
 	}
-	rc.History = rc.History[:len(rc.History)-1]
-	rc.currentPath = rc.History[len(rc.History)-1]
-	c.Update()
+}
+
+// Forward navigates to the next path in history after going back.
+func (rc *RouterController) Forward(c *app.Ctx) {
+	if rc.CanForward() {
+		rc.move(c, rc.index+1, NavigationForward)
+	}
+}
+
+func (rc *RouterController) CanBack() bool {
+	return rc.index > 0
+}
+func (rc *RouterController) CanForward() bool {
+	return rc.index < len(rc.History)-1
 }
 
 // Current returns the current active path.
 func (rc *RouterController) Current() string {
-	return rc.currentPath
+	return rc.History[rc.index].Path
+}
+
+// State returns the state the current path was navigated to with.
+func (rc *RouterController) State() any {
+	return rc.History[rc.index].State
 }
 
 // Replace replaces the current path in history with a new one.
 func (rc *RouterController) Replace(c *app.Ctx, newPath string) {
-	cleanedPath := path.Clean(newPath)
-	if rc.currentPath == cleanedPath && len(rc.History) > 0 {
+	rc.navigate(c, newPath, NavigateOptions{Replace: true})
+}
+
+// ReplaceRoot clears history and navigates to a new root path.
+func (rc *RouterController) ReplaceRoot(c *app.Ctx, newPath string) {
+	rc.navigate(c, newPath, NavigateOptions{Reset: true})
+}
+
+// Navigate navigates to the path with the options.
+func (rc *RouterController) Navigate(c *app.Ctx, to string, opts ...NavigateOption) {
+	options := NavigateOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	rc.navigate(c, to, options)
+}
+
+// navigate changes the path unless a leave guard of the current routes
+// prevents it.
+func (rc *RouterController) navigate(c *app.Ctx, newPath string, options NavigateOptions) {
+	cleanedPath := cleanPath(newPath)
+	if rc.Current() == cleanedPath && !options.Reset {
+		// Staying on the path only changes its state.
+		if options.State != nil {
+			rc.History[rc.index].State = options.State
+			c.Update()
+		}
+		return
+	}
+	if !options.Force && !rc.canLeave(cleanedPath) {
 		return
 	}
-	rc.currentPath = cleanedPath
-	if len(rc.History) > 0 {
-		rc.History[len(rc.History)-1] = rc.currentPath
-	} else {
-		rc.History = append(rc.History, rc.currentPath)
+	from := rc.Current()
+	entry := HistoryEntry{Path: cleanedPath, State: options.State}
+	kind := NavigationPush
+	switch {
+	case options.Reset:
+		rc.History, rc.index = []HistoryEntry{entry}, 0
+		kind = NavigationReset
+	case options.Replace:
+		rc.History[rc.index] = entry
+		kind = NavigationReplace
+	default:
+		rc.History = append(rc.History[:rc.index+1], entry)
+		rc.index++
 	}
+	rc.notify(kind, from)
+	rc.redirect()
 	c.Update()
 }
 
-// ReplaceRoot clears history and navigates to a new root path.
-func (rc *RouterController) ReplaceRoot(c *app.Ctx, newPath string) {
-	cleanedPath := path.Clean(newPath)
-	rc.currentPath = cleanedPath
-	rc.History = make([]string, 1)
-	rc.History[0] = rc.currentPath
+// move goes to the entry of history at the index.
+func (rc *RouterController) move(c *app.Ctx, index int, kind NavigationKind) {
+	if !rc.canLeave(rc.History[index].Path) {
+		return
+	}
+	from := rc.Current()
+	rc.index = index
+	rc.notify(kind, from)
+	rc.redirect()
 	c.Update()
 }
 
+// canLeave calls the leave guards of the matched routes which the new path
+// does not match, innermost first.
+func (rc *RouterController) canLeave(newPath string) bool {
+	from := matchChain(rc.Routes, rc.Current())
+	to := matchChain(rc.Routes, newPath)
+	for i := len(from) - 1; i >= 0; i-- {
+		m := from[i]
+		if m.route.BeforeLeave == nil || (i < len(to) && to[i].route == m.route && to[i].prefix == m.prefix) {
+			continue
+		}
+		if !m.route.BeforeLeave(m.location, rc.locate(newPath)) {
+			return false
+		}
+	}
+	return true
+}
+
+// locate returns the location of the path with the params of the routes it
+// matches.
+func (rc *RouterController) locate(p string) Location {
+	if chain := matchChain(rc.Routes, p); len(chain) > 0 {
+		return chain[len(chain)-1].location
+	}
+	return location(p, nil)
+}
+
+// maxRedirects is how many enter guards can redirect in a row before the
+// router gives up. It stops guards redirecting to each other.
+const maxRedirects = 10
+
+// redirect follows the redirects of the enter guards of the matched routes.
+// A redirect replaces the current path in history and keeps its state.
+func (rc *RouterController) redirect() {
+	for range maxRedirects {
+		to := ""
+		for _, m := range matchChain(rc.Routes, rc.Current()) {
+			if m.route.BeforeEnter != nil {
+				if to = m.route.BeforeEnter(m.location); to != "" {
+					break
+				}
+			}
+		}
+		if to == "" || cleanPath(to) == rc.Current() {
+			return
+		}
+		from := rc.Current()
+		rc.History[rc.index].Path = cleanPath(to)
+		rc.notify(NavigationRedirect, from)
+	}
+	log.Printf("Router: too many redirects, stopped at %s", rc.Current())
+}
+
+// --- Navigation Events ---
+
+// NavigationKind is how the router got to a path.
+type NavigationKind int
+
+const (
+	NavigationPush NavigationKind = iota
+	NavigationReplace
+	NavigationReset
+	NavigationBack
+	NavigationForward
+	// NavigationRedirect is a redirect by an enter guard.
+	NavigationRedirect
+)
+
+func (k NavigationKind) String() string {
+	switch k {
+	case NavigationPush:
+		return "push"
+	case NavigationReplace:
+		return "replace"
+	case NavigationReset:
+		return "reset"
+	case NavigationBack:
+		return "back"
+	case NavigationForward:
+		return "forward"
+	case NavigationRedirect:
+		return "redirect"
+	}
+	return "unknown"
+}
+
+// NavigationEvent is sent to UseNavigationEvents after the path has changed.
+type NavigationEvent struct {
+	Kind  NavigationKind
+	From  Location
+	To    Location
+	State any
+}
+
+type navigationListener struct {
+	handler func(NavigationEvent)
+}
+
+func (rc *RouterController) notify(kind NavigationKind, from string) {
+	if len(rc.listeners) == 0 {
+		return
+	}
+	event := NavigationEvent{
+		Kind:  kind,
+		From:  rc.locate(from),
+		To:    rc.locate(rc.Current()),
+		State: rc.State(),
+	}
+	for _, listener := range slices.Clone(rc.listeners) {
+		listener.handler(event)
+	}
+}
+
+// UseNavigationEvents calls the handler after every navigation while the
+// component is rendered, like for analytics.
+func UseNavigationEvents(c *app.Ctx, handler func(NavigationEvent)) {
+	routerCtrl := UseRouterController(c)
+	listener, _ := app.UseState(c, &navigationListener{})
+	listener.handler = handler
+
+	app.UseEffectWithCleanup(c, func() func() {
+		routerCtrl.listeners = append(routerCtrl.listeners, listener)
+		return func() {
+			routerCtrl.listeners = slices.DeleteFunc(routerCtrl.listeners, func(l *navigationListener) bool {
+				return l == listener
+			})
+		}
+	}, []any{routerCtrl})
+}
+
+// UseNavigationState returns the state the current path was navigated to
+// with. It is false when there is no state of the type.
+func UseNavigationState[T any](c *app.Ctx) (T, bool) {
+	state, ok := UseRouterController(c).State().(T)
+	return state, ok
+}
+
 // --- Contexts ---
 
 // RouterContext holds the global RouterController instance.
@@ -115,8 +356,9 @@ func UseRouterController(c *app.Ctx) *RouterController {
 type CurrentMatchContextData struct {
 	MatchedRoute      *Route
 	PathParams        map[string]string
-	RemainingPath     string // The part of the URL not matched by this route, for Outlets
-	MatchedPathPrefix string // The full path prefix matched by this route and its parents
+	Query             url.Values // The query parameters of the current path
+	RemainingPath     string     // The part of the URL not matched by this route, for Outlets
+	MatchedPathPrefix string     // The full path prefix matched by this route and its parents
 }
 
 // CurrentMatchContext holds data for the current route match.
@@ -133,6 +375,9 @@ func UseCurrentMatch(c *app.Ctx) CurrentMatchContextData {
 // NewRouter is the entry point to set up the router.
 // It provides the RouterController to its children.
 func NewRouter(c *app.Ctx, props RouterProps) *app.C {
+	if props.KeepAliveMax == 0 {
+		props.KeepAliveMax = 10
+	}
 
 	ps := RouterViewProps{
 		Layout: app.Layout{
@@ -153,11 +398,82 @@ type RouterViewProps struct {
 func routerView(c *app.Ctx, rawProps app.Props) string {
 	props, _ := rawProps.(RouterViewProps)
 
-	routerCtrl, _ := app.UseState(c, NewRouterController(props.InitialPath, props.Routes, props.NotFound))
-
-	return context.NewProvider(c, RouterContext, routerCtrl, func(c *app.Ctx) *app.C {
-		return matchAndRender(c, routerCtrl.Routes, routerCtrl.currentPath, routerCtrl.currentPath, "", routerCtrl.notFound)
+	// The controller is created on the first render only, so the enter
+	// guards of the initial path run once.
+	routerCtrl, _ := app.UseState(c, &RouterController{})
+	if routerCtrl.History == nil {
+		*routerCtrl = *NewRouterController(props.InitialPath, props.Routes, props.NotFound)
+	}
+	// The routes are taken from every render so the guards see the current
+	// state of the component rendering the router.
+	routerCtrl.Routes, routerCtrl.notFound = props.Routes, props.NotFound
+	routerCtrl.transition = props.Transition
+
+	content := context.NewProvider(c, RouterContext, routerCtrl, func(c *app.Ctx) *app.C {
+		currentPath, _, _ := strings.Cut(routerCtrl.Current(), "?")
+		return renderRoutes(c, routerCtrl, routerCtrl.Routes, currentPath, "")
 	}).String()
+
+	if c.LayoutPhase == app.LayoutPhaseFinalRender {
+		routerCtrl.updateKept(c, props.KeepAliveMax)
+	}
+	return content
+}
+
+// cleanPath cleans the path and keeps the query after it.
+func cleanPath(p string) string {
+	p, query, hasQuery := strings.Cut(p, "?")
+	p = path.Clean(p)
+	if hasQuery && query != "" {
+		return p + "?" + query
+	}
+	return p
+}
+
+// location splits the path into a Location.
+func location(p string, params map[string]string) Location {
+	pathOnly, rawQuery, _ := strings.Cut(p, "?")
+	query, _ := url.ParseQuery(rawQuery)
+	return Location{URL: p, Path: pathOnly, Query: query, Params: params}
+}
+
+// routeMatch is a route matched by matchChain.
+type routeMatch struct {
+	route    *Route
+	prefix   string
+	location Location
+}
+
+// matchChain returns the routes matching the path the way they are rendered
+// with outlets, from the outermost to the innermost.
+func matchChain(routes []Route, p string) []routeMatch {
+	var chain []routeMatch
+	segment, _, _ := strings.Cut(p, "?")
+	prefix := ""
+	params := map[string]string{}
+	for len(routes) > 0 {
+		segment = path.Clean(segment)
+		if segment == "." {
+			segment = "/"
+		}
+		found := false
+		for i := range routes {
+			routeParams, matched, consumed, remaining := matchRoute(routes[i].Path, segment)
+			if !matched {
+				continue
+			}
+			params = maps.Clone(params)
+			maps.Copy(params, routeParams)
+			prefix = path.Join(prefix, consumed)
+			chain = append(chain, routeMatch{route: &routes[i], prefix: prefix, location: location(p, params)})
+			routes, segment, found = routes[i].Children, remaining, true
+			break
+		}
+		if !found {
+			break
+		}
+	}
+	return chain
 }
 
 // --- Matching Logic ---
@@ -196,31 +512,27 @@ func matchRoute(routeDefPath, currentUrlSegment string) (map[string]string, bool
 		return nil, false, "", ""
 	}
 
-	if len(currentParts) < len(defParts) {
+	if cleanCurrentUrlSegment == "" { // The root "/" has no segments
+		currentParts = nil
+	}
+	required := 0
+	for _, defPart := range defParts {
+		if !strings.HasPrefix(defPart, "*") && !strings.HasSuffix(defPart, "?") {
+			required++
+		}
+	}
+	if len(currentParts) < required {
 		return nil, false, "", ""
 	}
 	if len(defParts) == 1 && defParts[0] == "" && cleanRouteDefPath != "" {
 		return nil, false, "", ""
 	}
 
-	pathConsumedParts := make([]string, 0, len(defParts))
-
-	for i, defPart := range defParts {
-		if i >= len(currentParts) { // Not enough segments in current URL
-			return nil, false, "", ""
-		}
-		currentPart := currentParts[i]
-
-		if strings.HasPrefix(defPart, ":") {
-			paramName := defPart[1:]
-			params[paramName] = currentPart
-			pathConsumedParts = append(pathConsumedParts, currentPart)
-		} else if defPart == currentPart {
-			pathConsumedParts = append(pathConsumedParts, currentPart)
-		} else {
-			return nil, false, "", ""
-		}
+	matchedParts, ok := matchParts(defParts, currentParts, params)
+	if !ok {
+		return nil, false, "", ""
 	}
+	pathConsumedParts := currentParts[:matchedParts]
 
 	consumedPath := strings.Join(pathConsumedParts, "/")
 	// Ensure consumedPath has a leading slash if currentUrlSegment did, and it's not empty.
@@ -229,9 +541,9 @@ func matchRoute(routeDefPath, currentUrlSegment string) (map[string]string, bool
 	}
 
 	remainingPath := ""
-	if len(currentParts) > len(defParts) {
-		remainingPath = "/" + strings.Join(currentParts[len(defParts):], "/")
-	} else if len(currentParts) == len(defParts) && strings.HasSuffix(currentUrlSegment, "/") && len(defParts) > 0 {
+	if len(currentParts) > matchedParts {
+		remainingPath = "/" + strings.Join(currentParts[matchedParts:], "/")
+	} else if len(currentParts) == matchedParts && strings.HasSuffix(currentUrlSegment, "/") && len(defParts) > 0 {
 		// If currentUrlSegment was "/foo/" and matched "/foo", remaining should be "/"
 		// This is tricky. path.Clean might simplify.
 		// For now, if exact match of all parts, remaining is empty unless original had trailing slash.
@@ -246,6 +558,74 @@ func matchRoute(routeDefPath, currentUrlSegment string) (map[string]string, bool
 	return params, true, consumedPath, remainingPath
 }
 
+// matchParts matches the route segments against the start of the URL
+// segments and returns how many URL segments it matched. An optional segment
+// is skipped when the rest of the route only matches without it, so
+// "users/:id?/edit" matches "/users/edit". The params are only set for the
+// segments of the match that is returned.
+func matchParts(defParts, currentParts []string, params map[string]string) (int, bool) {
+	if len(defParts) == 0 {
+		return 0, true
+	}
+	if name, isSplat := strings.CutPrefix(defParts[0], "*"); isSplat {
+		// A splat matches the rest of the URL, also when there is none.
+		if name == "" {
+			name = "*"
+		}
+		params[name] = strings.Join(currentParts, "/")
+		return len(currentParts), true
+	}
+	defPart, optional := strings.CutSuffix(defParts[0], "?")
+	if len(currentParts) > 0 {
+		paramName, isParam := strings.CutPrefix(defPart, ":")
+		if isParam || defPart == currentParts[0] {
+			if matched, ok := matchParts(defParts[1:], currentParts[1:], params); ok {
+				if isParam {
+					params[paramName] = currentParts[0]
+				}
+				return matched + 1, true
+			}
+		}
+	}
+	if optional {
+		return matchParts(defParts[1:], currentParts, params)
+	}
+	return 0, false
+}
+
+// renderRoutes renders the route matching the path segment, in a transition
+// when the router has one.
+// Outlets do not transition when they are mounted as the route around them
+// already does.
+func renderRoutes(c *app.Ctx, routerCtrl *RouterController, routes []Route, pathSegmentToMatch string, accumulatedParentPrefix string) *app.C {
+	render := func(c *app.Ctx) *app.C {
+		return matchAndRender(c, routes, routerCtrl.Current(), pathSegmentToMatch, accumulatedParentPrefix, routerCtrl.notFound)
+	}
+	if routerCtrl.transition == nil {
+		return render(c)
+	}
+	return transition.New(c, render,
+		transition.WithEffect(*routerCtrl.transition),
+		transition.WithTrigger(matchedPrefix(routes, pathSegmentToMatch, accumulatedParentPrefix)),
+		transition.WithAppear(accumulatedParentPrefix == ""),
+	)
+}
+
+// matchedPrefix returns the path prefix of the route matching the path
+// segment, or "" when none does.
+func matchedPrefix(routes []Route, pathSegmentToMatch string, accumulatedParentPrefix string) string {
+	segment := path.Clean(pathSegmentToMatch)
+	if segment == "." {
+		segment = "/"
+	}
+	for _, route := range routes {
+		if _, matched, consumed, _ := matchRoute(route.Path, segment); matched {
+			return path.Join(accumulatedParentPrefix, consumed)
+		}
+	}
+	return ""
+}
+
 type keyProps struct {
 	Key string
 	app.Layout
@@ -263,6 +643,9 @@ func matchAndRender(
 	accumulatedParentPrefix string,
 	notFound app.FC,
 ) *app.C {
+	_, rawQuery, _ := strings.Cut(fullUrl, "?")
+	query, _ := url.ParseQuery(rawQuery)
+
 	normalizedPathSegmentToMatch := path.Clean(pathSegmentToMatch)
 	if normalizedPathSegmentToMatch == "." { // path.Clean can return "." for empty or "/"
 		normalizedPathSegmentToMatch = "/"
@@ -278,6 +661,7 @@ func matchAndRender(
 			newMatchData := CurrentMatchContextData{
 				MatchedRoute:      &routeCopy,
 				PathParams:        params,
+				Query:             query,
 				RemainingPath:     remainingPathForChildren,
 				MatchedPathPrefix: path.Join(accumulatedParentPrefix, pathConsumed),
 			}
@@ -286,7 +670,13 @@ func matchAndRender(
 			// via CurrentMatchContext.
 			return context.NewProvider(c, CurrentMatchContext, newMatchData, func(c *app.Ctx) *app.C {
 				if routeCopy.Component != nil {
-					return c.RenderWithName(func(c *app.Ctx, props app.Props) string { return routeCopy.Component(c).String() }, keyProps{
+					return c.RenderWithName(func(c *app.Ctx, props app.Props) string {
+						if !routeCopy.KeepAlive {
+							return routeCopy.Component(c).String()
+						}
+						kept := UseRouterController(c).keep(c, app.UseID(c))
+						return context.NewProvider(c, keptRouteContext, kept, routeCopy.Component).String()
+					}, keyProps{
 						Layout: app.Layout{
 							GrowX: true,
 							GrowY: true,
@@ -334,13 +724,12 @@ func outlet(c *app.Ctx, _ app.Props) string {
 		return ""
 	}
 
-	return matchAndRender(
+	return renderRoutes(
 		c,
+		routerCtrl,
 		currentMatch.MatchedRoute.Children,
-		routerCtrl.Current(),
 		currentMatch.RemainingPath,
 		currentMatch.MatchedPathPrefix,
-		routerCtrl.notFound,
 	).String()
 }
 
@@ -350,6 +739,11 @@ func outlet(c *app.Ctx, _ app.Props) string {
 type NavigateOptions struct {
 	Replace bool
 	Reset   bool
+	// State is kept with the path in history. Get it with UseNavigationState.
+	State any
+	// Force navigates without calling the leave guards, like after asking
+	// to discard unsaved changes.
+	Force bool
 }
 
 // NavigateOption defines a function that modifies NavigateOptions.
@@ -368,6 +762,20 @@ func WithReset(reset bool) NavigateOption {
 	}
 }
 
+// WithState is an option for Navigate to pass a value to the destination.
+func WithState(state any) NavigateOption {
+	return func(o *NavigateOptions) {
+		o.State = state
+	}
+}
+
+// WithForce is an option for Navigate to skip the leave guards.
+func WithForce(force bool) NavigateOption {
+	return func(o *NavigateOptions) {
+		o.Force = force
+	}
+}
+
 // Navigate allows programmatic navigation.
 func Navigate(c *app.Ctx, to string, opts ...NavigateOption) {
 	routerCtrl := UseRouterController(c)
@@ -375,17 +783,5 @@ func Navigate(c *app.Ctx, to string, opts ...NavigateOption) {
 		log.Println("Navigate: RouterController not found.")
 		return
 	}
-
-	options := NavigateOptions{}
-	for _, opt := range opts {
-		opt(&options)
-	}
-
-	if options.Reset {
-		routerCtrl.ReplaceRoot(c, to)
-	} else if options.Replace {
-		routerCtrl.Replace(c, to)
-	} else {
-		routerCtrl.Push(c, to)
-	}
+	routerCtrl.Navigate(c, to, opts...)
 }
@@ -0,0 +1,105 @@
+package router
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestMatchRoute(t *testing.T) {
+	tests := []struct {
+		name      string
+		route     string
+		path      string
+		params    map[string]string
+		matched   bool
+		consumed  string
+		remaining string
+	}{
+		{name: "root", route: "/", path: "/", matched: true, remaining: "/"},
+		{name: "root does not match other paths", route: "/", path: "/users"},
+		{name: "index", route: "", path: "/", matched: true, remaining: "/"},
+		{name: "index does not match other paths", route: "", path: "/users"},
+		{name: "literal", route: "users", path: "/users", matched: true, consumed: "/users"},
+		{name: "literal prefix", route: "users", path: "/users/1/posts", matched: true, consumed: "/users", remaining: "/1/posts"},
+		{name: "literal mismatch", route: "users", path: "/teams"},
+		{name: "param", route: "users/:id", path: "/users/42", params: map[string]string{"id": "42"}, matched: true, consumed: "/users/42"},
+		{name: "param missing", route: "users/:id", path: "/users"},
+		{name: "optional param given", route: "users/:id?", path: "/users/42", params: map[string]string{"id": "42"}, matched: true, consumed: "/users/42"},
+		{name: "optional param left out", route: "users/:id?", path: "/users", matched: true, consumed: "/users"},
+		{name: "optional param before literal given", route: "users/:id?/edit", path: "/users/7/edit", params: map[string]string{"id": "7"}, matched: true, consumed: "/users/7/edit"},
+		{name: "optional param before literal left out", route: "users/:id?/edit", path: "/users/edit", matched: true, consumed: "/users/edit"},
+		{name: "optional param before literal mismatch", route: "users/:id?/edit", path: "/users/7/view"},
+		{name: "optional literal given", route: "docs/latest?/:page", path: "/docs/latest/intro", params: map[string]string{"page": "intro"}, matched: true, consumed: "/docs/latest/intro"},
+		{name: "optional literal left out", route: "docs/latest?/:page", path: "/docs/intro", params: map[string]string{"page": "intro"}, matched: true, consumed: "/docs/intro"},
+		{name: "splat", route: "files/*", path: "/files/a/b", params: map[string]string{"*": "a/b"}, matched: true, consumed: "/files/a/b"},
+		{name: "named splat", route: "files/*rest", path: "/files/a/b", params: map[string]string{"rest": "a/b"}, matched: true, consumed: "/files/a/b"},
+		{name: "splat without rest", route: "files/*rest", path: "/files", params: map[string]string{"rest": ""}, matched: true, consumed: "/files"},
+		{name: "splat after optional", route: "files/:id?/*", path: "/files/a/b", params: map[string]string{"id": "a", "*": "b"}, matched: true, consumed: "/files/a/b"},
+		{name: "catch-all", route: "*", path: "/anything/here", params: map[string]string{"*": "anything/here"}, matched: true, consumed: "/anything/here"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, matched, consumed, remaining := matchRoute(tt.route, tt.path)
+			if matched != tt.matched {
+				t.Fatalf("matchRoute(%q, %q) matched = %v, want %v", tt.route, tt.path, matched, tt.matched)
+			}
+			if !matched {
+				return
+			}
+			if !maps.Equal(params, tt.params) {
+				t.Errorf("matchRoute(%q, %q) params = %v, want %v", tt.route, tt.path, params, tt.params)
+			}
+			if consumed != tt.consumed {
+				t.Errorf("matchRoute(%q, %q) consumed = %q, want %q", tt.route, tt.path, consumed, tt.consumed)
+			}
+			if remaining != tt.remaining {
+				t.Errorf("matchRoute(%q, %q) remaining = %q, want %q", tt.route, tt.path, remaining, tt.remaining)
+			}
+		})
+	}
+}
+
+func TestMatchChainQuery(t *testing.T) {
+	routes := []Route{
+		{Path: "/"},
+		{Path: "search"},
+		{Path: "users/:id", Children: []Route{
+			{Path: "files/*rest"},
+		}},
+	}
+	tests := []struct {
+		name   string
+		path   string
+		depth  int
+		params map[string]string
+		query  map[string]string
+	}{
+		{name: "no query", path: "/search", depth: 1},
+		{name: "query", path: "/search?q=go&page=2", depth: 1, query: map[string]string{"q": "go", "page": "2"}},
+		{name: "empty query", path: "/search?", depth: 1},
+		{name: "query after params", path: "/users/3/files/a/b?sort=name", depth: 2, params: map[string]string{"id": "3", "rest": "a/b"}, query: map[string]string{"sort": "name"}},
+		{name: "query on root", path: "/?tab=1", depth: 1, query: map[string]string{"tab": "1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := matchChain(routes, tt.path)
+			if len(chain) != tt.depth {
+				t.Fatalf("matchChain(%q) matched %d routes, want %d", tt.path, len(chain), tt.depth)
+			}
+			location := chain[len(chain)-1].location
+			if location.URL != tt.path {
+				t.Errorf("matchChain(%q) URL = %q", tt.path, location.URL)
+			}
+			if !maps.Equal(location.Params, tt.params) {
+				t.Errorf("matchChain(%q) params = %v, want %v", tt.path, location.Params, tt.params)
+			}
+			query := map[string]string{}
+			for key := range location.Query {
+				query[key] = location.Query.Get(key)
+			}
+			if !maps.Equal(query, tt.query) {
+				t.Errorf("matchChain(%q) query = %v, want %v", tt.path, query, tt.query)
+			}
+		})
+	}
+}
@@ -0,0 +1,428 @@
+package splitpane
+
+import (
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/style"
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// Pane identifies one of the two panes of a split pane.
+type Pane int
+
+const (
+	None Pane = iota
+	First
+	Second
+)
+
+// Props defines the properties for the SplitPane component.
+// The panes are laid out side by side when Layout.Direction is app.Horizontal
+// and on top of each other when it is app.Vertical.
+type Props struct {
+	Key       string
+	First     app.FC
+	Second    app.FC
+	Ratio     float64 // Initial share of the first pane (0-1)
+	MinFirst  int     // Minimum size of the first pane in cells
+	MinSecond int     // Minimum size of the second pane in cells
+	OnChange  func(ratio float64, collapsed Pane)
+	KeyMap    KeyMap
+	Styles    style.SplitPaneTheme
+	app.Layout
+}
+
+type prop func(*Props)
+
+type KeyMap struct {
+	Decrease       key.Binding
+	Increase       key.Binding
+	DecreaseMore   key.Binding
+	IncreaseMore   key.Binding
+	CollapseFirst  key.Binding
+	CollapseSecond key.Binding
+	Restore        key.Binding
+}
+
+func defaultKeyMap() KeyMap {
+	return KeyMap{
+		Decrease: key.NewBinding(
+			key.WithKeys("left", "up", "h", "k"),
+			key.WithHelp("←/↑", "shrink first pane"),
+		),
+		Increase: key.NewBinding(
+			key.WithKeys("right", "down", "l", "j"),
+			key.WithHelp("→/↓", "grow first pane"),
+		),
+		DecreaseMore: key.NewBinding(
+			key.WithKeys("shift+left", "shift+up", "H", "K"),
+			key.WithHelp("shift+←/↑", "shrink first pane more"),
+		),
+		IncreaseMore: key.NewBinding(
+			key.WithKeys("shift+right", "shift+down", "L", "J"),
+			key.WithHelp("shift+→/↓", "grow first pane more"),
+		),
+		CollapseFirst: key.NewBinding(
+			key.WithKeys("home"),
+			key.WithHelp("home", "collapse first pane"),
+		),
+		CollapseSecond: key.NewBinding(
+			key.WithKeys("end"),
+			key.WithHelp("end", "collapse second pane"),
+		),
+		Restore: key.NewBinding(
+			key.WithKeys("enter", "space"),
+			key.WithHelp("enter", "expand collapsed pane"),
+		),
+	}
+}
+
+// dividerSize is the number of cells the divider takes up between the panes.
+const dividerSize = 1
+
+// fastStep is the number of cells the divider moves with DecreaseMore/IncreaseMore.
+const fastStep = 5
+
+// ratioPrecision is the total weight shared by the two panes in the layout engine.
+const ratioPrecision = 10000
+
+type splitState struct {
+	ratio     float64
+	collapsed Pane
+	dragging  bool
+}
+
+// SplitPane is the functional component for rendering two resizable panes.
+func SplitPane(c *app.Ctx, rawProps app.Props) string {
+	props, ok := rawProps.(Props)
+	if !ok {
+		panic("SplitPane: props must be of type splitpane.Props")
+	}
+
+	state, setState := app.UseState(c, splitState{ratio: clampRatio(props.Ratio, 0, 1)})
+
+	width, height := app.UseSize(c)
+	x, y := app.UseGlobalPosition(c)
+
+	horizontal := props.Layout.Direction == app.Horizontal
+	size, offset := height, y
+	if horizontal {
+		size, offset = width, x
+	}
+	available := max(size-dividerSize, 0)
+
+	update := func(next splitState) {
+		if available > 0 {
+			next.ratio = clampRatio(next.ratio, float64(props.MinFirst)/float64(available), 1-float64(props.MinSecond)/float64(available))
+		}
+		changed := next.ratio != state.ratio || next.collapsed != state.collapsed
+		setState(next)
+		if changed && props.OnChange != nil {
+			props.OnChange(next.ratio, next.collapsed)
+		}
+	}
+
+	resizeBy := func(cells int) {
+		if available <= 0 {
+			return
+		}
+		next := state
+		next.collapsed = None
+		next.ratio = state.ratio + float64(cells)/float64(available)
+		update(next)
+	}
+
+	collapse := func(pane Pane) {
+		next := state
+		next.collapsed = pane
+		update(next)
+	}
+
+	app.UseMouseHandler(c, func(msg tea.MouseMsg, childID string) bool {
+		if !state.dragging {
+			return false
+		}
+		switch msg := msg.(type) {
+		case tea.MouseMotionMsg:
+			if msg.Button != tea.MouseLeft {
+				next := state
+				next.dragging = false
+				setState(next)
+				return false
+			}
+			if available <= 0 {
+				return true
+			}
+			pos := msg.Y - offset
+			if horizontal {
+				pos = msg.X - offset
+			}
+			next := state
+			next.collapsed = None
+			next.ratio = float64(pos) / float64(available)
+			update(next)
+			return true
+		case tea.MouseReleaseMsg:
+			next := state
+			next.dragging = false
+			setState(next)
+			return true
+		}
+		return false
+	})
+
+	paneLayout := func(weight int, minSize int) app.Layout {
+		layout := app.Layout{GrowX: true, GrowY: true, Weight: max(weight, 1)}
+		if horizontal {
+			layout.MinWidth = minSize
+		} else {
+			layout.MinHeight = minSize
+		}
+		return layout
+	}
+
+	firstWeight := int(math.Round(state.ratio * ratioPrecision))
+
+	var rendered []string
+	if state.collapsed != First && props.First != nil {
+		rendered = append(rendered, c.Render(pane, paneProps{
+			Key:    "first",
+			Child:  props.First,
+			Layout: paneLayout(firstWeight, props.MinFirst),
+		}).String())
+	}
+	rendered = append(rendered, c.Render(divider, dividerProps{
+		Horizontal:  horizontal,
+		Collapsed:   state.collapsed,
+		Dragging:    state.dragging,
+		Styles:      props.Styles,
+		KeyMap:      props.KeyMap,
+		OnResize:    resizeBy,
+		OnCollapse:  collapse,
+		OnDragStart: func() { next := state; next.dragging = true; setState(next) },
+		Layout:      app.Layout{GrowX: !horizontal, GrowY: horizontal},
+	}).String())
+	if state.collapsed != Second && props.Second != nil {
+		rendered = append(rendered, c.Render(pane, paneProps{
+			Key:    "second",
+			Child:  props.Second,
+			Layout: paneLayout(ratioPrecision-firstWeight, props.MinSecond),
+		}).String())
+	}
+
+	var result string
+	if horizontal {
+		result = lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+	} else {
+		result = lipgloss.JoinVertical(lipgloss.Left, rendered...)
+	}
+
+	s := lipgloss.NewStyle()
+	if c.CurrentBg != nil {
+		s = s.Background(c.CurrentBg)
+	}
+
+	return c.MouseZone(s.Width(width).Height(height).Render(result))
+}
+
+type paneProps struct {
+	Key   string
+	Child app.FC
+	app.Layout
+}
+
+// pane renders a child clipped to the size given to it by the layout engine.
+func pane(c *app.Ctx, rawProps app.Props) string {
+	props, _ := rawProps.(paneProps)
+
+	width, height := app.UseSize(c)
+
+	content := props.Child(c).String()
+
+	s := lipgloss.NewStyle()
+	if c.CurrentBg != nil {
+		s = s.Background(c.CurrentBg)
+	}
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+	return s.Width(width).Height(height).MaxWidth(width).MaxHeight(height).Render(content)
+}
+
+type dividerProps struct {
+	Horizontal  bool
+	Collapsed   Pane
+	Dragging    bool
+	Styles      style.SplitPaneTheme
+	KeyMap      KeyMap
+	OnResize    func(cells int)
+	OnCollapse  func(pane Pane)
+	OnDragStart func()
+	app.Layout
+}
+
+// divider is the focusable and draggable handle between the panes.
+func divider(c *app.Ctx, rawProps app.Props) string {
+	props, _ := rawProps.(dividerProps)
+
+	id := app.UseID(c)
+	focused := app.UseIsFocused(c)
+	hovered, _ := app.UseIsHovered(c)
+
+	app.UseKeyHandler(c, func(keyMsg tea.KeyMsg) bool {
+		switch {
+		case key.Matches(keyMsg, props.KeyMap.Decrease):
+			props.OnResize(-1)
+		case key.Matches(keyMsg, props.KeyMap.Increase):
+			props.OnResize(1)
+		case key.Matches(keyMsg, props.KeyMap.DecreaseMore):
+			props.OnResize(-fastStep)
+		case key.Matches(keyMsg, props.KeyMap.IncreaseMore):
+			props.OnResize(fastStep)
+		case key.Matches(keyMsg, props.KeyMap.CollapseFirst):
+			props.OnCollapse(First)
+		case key.Matches(keyMsg, props.KeyMap.CollapseSecond):
+			props.OnCollapse(Second)
+		case key.Matches(keyMsg, props.KeyMap.Restore):
+			if props.Collapsed == None {
+				return false
+			}
+			props.OnCollapse(None)
+		default:
+			return false
+		}
+		return true
+	})
+
+	app.UseMouseHandler(c, func(msg tea.MouseMsg, childID string) bool {
+		if click, ok := msg.(tea.MouseClickMsg); ok && click.Button == tea.MouseLeft {
+			c.FocusThis(id)
+			props.OnDragStart()
+			return true
+		}
+		return false
+	})
+
+	s := props.Styles.Divider
+	if props.Dragging {
+		s = props.Styles.DividerActive
+	} else if hovered {
+		s = props.Styles.DividerHover
+	} else if focused {
+		s = props.Styles.DividerFocus
+	}
+	if c.CurrentBg != nil {
+		s = s.Background(c.CurrentBg)
+	}
+
+	width, height := app.UseSize(c)
+	if props.Horizontal {
+		if height <= 0 {
+			return ""
+		}
+		return c.MouseZone(s.Render(strings.TrimSuffix(strings.Repeat(props.Styles.HorizontalChar+"\n", height), "\n")))
+	}
+	if width <= 0 {
+		return ""
+	}
+	return c.MouseZone(s.Render(strings.Repeat(props.Styles.VerticalChar, width)))
+}
+
+func clampRatio(ratio, low, high float64) float64 {
+	low = max(low, 0)
+	high = min(high, 1)
+	if high < low {
+		return low
+	}
+	return min(max(ratio, low), high)
+}
+
+// New creates a new split pane with the two given panes.
+// By default the panes are side by side and share the space equally.
+func New(c *app.Ctx, first app.FC, second app.FC, opts ...prop) *app.C {
+	p := Props{
+		First:  first,
+		Second: second,
+		Ratio:  0.5,
+		KeyMap: defaultKeyMap(),
+		Styles: c.Theme.SplitPane,
+		Layout: app.Layout{
+			Direction: app.Horizontal,
+			GrowX:     true,
+			GrowY:     true,
+		},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&p)
+		}
+	}
+	return c.Render(SplitPane, p)
+}
+
+// --- Prop Option Functions ---
+
+func WithKey(key string) prop {
+	return func(props *Props) {
+		props.Key = key
+	}
+}
+
+// WithDirection sets whether the panes are side by side (app.Horizontal) or stacked (app.Vertical).
+func WithDirection(direction app.LayoutDirection) prop {
+	return func(props *Props) {
+		props.Layout.Direction = direction
+	}
+}
+
+// WithRatio sets the initial share of the first pane between 0 and 1.
+func WithRatio(ratio float64) prop {
+	return func(props *Props) {
+		props.Ratio = ratio
+	}
+}
+
+// WithMinSizes sets the minimum size in cells of the first and second pane.
+func WithMinSizes(first, second int) prop {
+	return func(props *Props) {
+		props.MinFirst = first
+		props.MinSecond = second
+	}
+}
+
+// WithOnChange sets a callback that is called when the ratio or collapsed pane changes.
+func WithOnChange(onChange func(ratio float64, collapsed Pane)) prop {
+	return func(props *Props) {
+		props.OnChange = onChange
+	}
+}
+
+func WithKeyMap(keyMap KeyMap) prop {
+	return func(props *Props) {
+		props.KeyMap = keyMap
+	}
+}
+
+// WithDividerColor sets the color of the divider when it is not hovered or focused.
+func WithDividerColor(color color.Color) prop {
+	return func(props *Props) {
+		props.Styles.Divider = props.Styles.Divider.Foreground(color)
+	}
+}
+
+func WithGrowX(grow bool) prop {
+	return func(props *Props) {
+		props.Layout.GrowX = grow
+	}
+}
+
+func WithGrowY(grow bool) prop {
+	return func(props *Props) {
+		props.Layout.GrowY = grow
+	}
+}
@@ -49,9 +49,14 @@ func Stack(c *app.Ctx, props app.Props) string {
 		result = lipgloss.JoinVertical(lipgloss.Left, processedFCs...)
 	}
 
-	s := lipgloss.NewStyle()
+	s := lipgloss.NewStyle().Width(w)
+	// A stack that does not grow is as high as its children. The height is
+	// the full screen while the heights are measured.
+	if stackProps.GrowY || stackProps.Height > 0 {
+		s = s.Height(h)
+	}
 
-	return s.Width(w).Height(h).Render(result)
+	return s.Render(result)
 }
 
 func New(c *app.Ctx, fcs app.FCs, props ...StackProp) *app.C {
@@ -0,0 +1,115 @@
+package table
+
+import (
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/style"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// Align is the horizontal alignment of the content in a cell.
+type Align int
+
+const (
+	// AlignDefault uses the alignment of the column which is left by default.
+	AlignDefault Align = iota
+	AlignLeft
+	AlignCenter
+	AlignRight
+)
+
+func (a Align) position() lipgloss.Position {
+	switch a {
+	case AlignCenter:
+		return lipgloss.Center
+	case AlignRight:
+		return lipgloss.Right
+	default:
+		return lipgloss.Left
+	}
+}
+
+// Cell describes how a single cell is rendered. The value in the Row is
+// still what the table sorts and filters by.
+type Cell struct {
+	// Text is shown instead of the value in the row when not empty.
+	Text  string
+	Align Align
+	Style lipgloss.Style
+	// Content is rendered within the width of the cell instead of the text.
+	Content app.FC
+
+	variant    style.Variant
+	hasVariant bool
+}
+
+// TextCell creates a cell showing the given text.
+func TextCell(text string) Cell {
+	return Cell{Text: text}
+}
+
+// ComponentCell creates a cell rendering the component within the cell.
+func ComponentCell(content app.FC) Cell {
+	return Cell{Content: content}
+}
+
+// WithAlign returns a copy of the cell with the alignment set.
+func (cell Cell) WithAlign(align Align) Cell {
+	cell.Align = align
+	return cell
+}
+
+// WithStyle returns a copy of the cell with the style set.
+func (cell Cell) WithStyle(s lipgloss.Style) Cell {
+	cell.Style = s
+	return cell
+}
+
+// WithVariant returns a copy of the cell colored like text of the variant.
+func (cell Cell) WithVariant(variant style.Variant) Cell {
+	cell.variant = variant
+	cell.hasVariant = true
+	return cell
+}
+
+// renderCell renders the value of a cell at the width of the column. The row
+// style fills in whatever the cell style leaves unset.
+func renderCell(c *app.Ctx, cell Cell, value string, col column, rowStyle lipgloss.Style, key string) string {
+	s := cell.Style
+	if cell.hasVariant {
+		s = s.Inherit(c.Theme.Text[cell.variant][style.Normal])
+	}
+	align := cell.Align
+	if align == AlignDefault {
+		align = col.Align
+	}
+	s = s.Inherit(rowStyle).Width(col.Width).MaxWidth(col.Width).MaxHeight(1).Align(align.position())
+
+	if cell.Content != nil {
+		return s.Render(c.Render(cellContent, cellContentProps{
+			Key:     key,
+			Content: cell.Content,
+			Layout:  app.Layout{Width: col.Width, Height: 1},
+		}).String())
+	}
+
+	text := value
+	if cell.Text != "" {
+		text = cell.Text
+	}
+	return s.Render(runewidth.Truncate(text, col.Width, "…"))
+}
+
+type cellContentProps struct {
+	Key     string
+	Content app.FC
+	app.Layout
+}
+
+// cellContent gives components in cells a fixed size and keeps their state
+// with the row and column instead of the position in the table.
+func cellContent(c *app.Ctx, rawProps app.Props) string {
+	props, _ := rawProps.(cellContentProps)
+	width, height := app.UseSize(c)
+	return lipgloss.NewStyle().MaxWidth(width).MaxHeight(height).Render(props.Content(c).String())
+}
@@ -0,0 +1,124 @@
+package table
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SortDirection is the order rows are sorted in by the sort column.
+type SortDirection int
+
+const (
+	SortNone SortDirection = iota
+	SortAsc
+	SortDesc
+)
+
+// next cycles through ascending, descending and no sorting.
+func (d SortDirection) next() SortDirection {
+	switch d {
+	case SortNone:
+		return SortAsc
+	case SortAsc:
+		return SortDesc
+	default:
+		return SortNone
+	}
+}
+
+// indicator is the glyph shown after the title of the sorted column.
+func (d SortDirection) indicator() string {
+	switch d {
+	case SortAsc:
+		return " ▲"
+	case SortDesc:
+		return " ▼"
+	default:
+		return ""
+	}
+}
+
+// Comparator compares two cell values. It returns a negative number when a
+// sorts before b, a positive number when a sorts after b and zero otherwise.
+type Comparator func(a, b string) int
+
+// CompareStrings compares the values alphabetically ignoring case.
+func CompareStrings(a, b string) int {
+	return cmp.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// CompareNumbers compares the values as numbers. Thousand separators, spaces
+// and a trailing % are ignored. Values that are not numbers sort last.
+func CompareNumbers(a, b string) int {
+	na, errA := parseNumber(a)
+	nb, errB := parseNumber(b)
+	switch {
+	case errA != nil && errB != nil:
+		return CompareStrings(a, b)
+	case errA != nil:
+		return 1
+	case errB != nil:
+		return -1
+	}
+	return cmp.Compare(na, nb)
+}
+
+func parseNumber(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(s, "%")
+	s = strings.ReplaceAll(s, ",", "")
+	s = strings.ReplaceAll(s, " ", "")
+	return strconv.ParseFloat(s, 64)
+}
+
+// defaultFilter matches rows where any cell contains the query ignoring case.
+func defaultFilter(row Row, query string) bool {
+	query = strings.ToLower(query)
+	for _, value := range row {
+		if strings.Contains(strings.ToLower(value), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// visibleRows returns the indices of the rows that match the filter in the
+// order given by the sort column.
+func visibleRows(cols []Column, rows []Row, state tableState, filter func(row Row, query string) bool) []int {
+	indices := make([]int, 0, len(rows))
+	if filter == nil {
+		filter = defaultFilter
+	}
+	for i, row := range rows {
+		if state.filter == "" || filter(row, state.filter) {
+			indices = append(indices, i)
+		}
+	}
+
+	if state.sortDirection == SortNone || state.sortColumn < 0 || state.sortColumn >= len(cols) {
+		return indices
+	}
+
+	compare := cols[state.sortColumn].Compare
+	if compare == nil {
+		compare = CompareStrings
+	}
+	column := state.sortColumn
+	slices.SortStableFunc(indices, func(a, b int) int {
+		result := compare(cellValue(rows[a], column), cellValue(rows[b], column))
+		if state.sortDirection == SortDesc {
+			return -result
+		}
+		return result
+	})
+	return indices
+}
+
+func cellValue(row Row, column int) string {
+	if column < len(row) {
+		return row[column]
+	}
+	return ""
+}
@@ -3,8 +3,11 @@
 package table
 
 import (
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/alexanderbh/bubbleapp/app"
 
@@ -18,10 +21,32 @@ import (
 
 // Props holds the configuration for the Table component.
 type Props struct {
-	DataFunc func(c *app.Ctx) (clms []Column, rows []Row)
-	KeyMap   KeyMap
-	Styles   Styles
-	Help     help.Model
+	DataFunc   func(c *app.Ctx) (clms []Column, rows []Row)
+	FilterFunc func(row Row, query string) bool
+	// CellFunc customizes the rendering of each cell. The row and column
+	// are indices into the data returned by DataFunc.
+	CellFunc func(c *app.Ctx, row, column int, value string) Cell
+	// RowStyle is applied to all cells in the row below the cursor and
+	// selection styles.
+	RowStyle func(row int, data Row) lipgloss.Style
+
+	// Multi allows selecting more than one row with space, shift+arrows and
+	// shift+click.
+	Multi bool
+	// Checkbox shows a checkbox in front of each row. It implies Multi.
+	Checkbox bool
+	// Selected makes the selection controlled when not nil. The table then
+	// never changes it and only reports changes through OnSelectionChange.
+	Selected []int
+
+	// The callbacks are given indices into the rows returned by DataFunc.
+	OnCursorChange    func(row int)
+	OnSelectionChange func(rows []int)
+	OnActivate        func(row int)
+
+	KeyMap KeyMap
+	Styles Styles
+	Help   help.Model
 	app.Margin
 	app.Layout
 }
@@ -30,11 +55,34 @@ type tableProp func(*Props)
 
 // tableState holds the internal state of the Table component.
 type tableState struct {
-	cols     []column
 	cursor   int
 	viewport viewport.Model
+
+	sortColumn    int
+	sortDirection SortDirection
+	headerCursor  int
+
+	filter    string
+	filtering bool
+
+	widths           map[int]int // Column widths set by resizing
+	resizing         int         // Index of the column being resized or -1
+	resizeStartX     int
+	resizeStartWidth int
+
+	selected  map[int]struct{} // Selected rows when the selection is not controlled
+	anchor    int              // Row where shift ranges start or -1
+	lastClick time.Time
+	lastRow   int // Row of the last click used to detect double clicks
 }
 
+// doubleClickInterval is the longest time between two clicks on the same row
+// for them to activate it.
+const doubleClickInterval = 400 * time.Millisecond
+
+// checkboxWidth is the width of the checkbox column.
+const checkboxWidth = 4
+
 type Row []string
 
 type ColumnWidth struct {
@@ -45,6 +93,13 @@ type ColumnWidth struct {
 type Column struct {
 	Title string
 	Width ColumnWidth
+	// Compare is used when sorting by this column.
+	// If nil the values are compared with CompareStrings.
+	Compare Comparator
+	// DisableSort prevents sorting by this column.
+	DisableSort bool
+	// Align is the alignment of the title and the cells in this column.
+	Align Align
 }
 
 func WidthGrow() ColumnWidth {
@@ -58,6 +113,7 @@ func WidthInt(i int) ColumnWidth {
 type column struct {
 	Title string
 	Width int
+	Align Align
 }
 
 type KeyMap struct {
@@ -69,16 +125,30 @@ type KeyMap struct {
 	HalfPageDown key.Binding
 	GotoTop      key.Binding
 	GotoBottom   key.Binding
+	PrevColumn   key.Binding
+	NextColumn   key.Binding
+	Sort         key.Binding
+	Filter       key.Binding
+	ClearFilter  key.Binding
+	AcceptFilter key.Binding
+	Toggle       key.Binding
+	ExtendUp     key.Binding
+	ExtendDown   key.Binding
+	SelectAll    key.Binding
+	SelectNone   key.Binding
+	Activate     key.Binding
 }
 
 func (km KeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{km.LineUp, km.LineDown}
+	return []key.Binding{km.LineUp, km.LineDown, km.Sort, km.Filter}
 }
 
 func (km KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{km.LineUp, km.LineDown, km.GotoTop, km.GotoBottom},
 		{km.PageUp, km.PageDown, km.HalfPageUp, km.HalfPageDown},
+		{km.PrevColumn, km.NextColumn, km.Sort, km.Filter},
+		{km.Toggle, km.ExtendUp, km.ExtendDown, km.SelectAll, km.SelectNone, km.Activate},
 	}
 }
 
@@ -116,16 +186,69 @@ func defaultKeyMap() KeyMap {
 			key.WithKeys("end", "G"),
 			key.WithHelp("G/end", "go to end"),
 		),
+		PrevColumn: key.NewBinding(
+			key.WithKeys("<", "left"),
+			key.WithHelp("</←", "previous column"),
+		),
+		NextColumn: key.NewBinding(
+			key.WithKeys(">", "right"),
+			key.WithHelp(">/→", "next column"),
+		),
+		Sort: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "sort by column"),
+		),
+		Filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter"),
+		),
+		ClearFilter: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "clear filter"),
+		),
+		AcceptFilter: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "apply filter"),
+		),
+		Toggle: key.NewBinding(
+			key.WithKeys("space"),
+			key.WithHelp("space", "toggle selection"),
+		),
+		ExtendUp: key.NewBinding(
+			key.WithKeys("shift+up", "K"),
+			key.WithHelp("shift+↑/K", "extend selection up"),
+		),
+		ExtendDown: key.NewBinding(
+			key.WithKeys("shift+down", "J"),
+			key.WithHelp("shift+↓/J", "extend selection down"),
+		),
+		SelectAll: key.NewBinding(
+			key.WithKeys("ctrl+a"),
+			key.WithHelp("ctrl+a", "select all"),
+		),
+		SelectNone: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "select none"),
+		),
+		Activate: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "activate"),
+		),
 	}
 }
 
 type Styles struct {
-	Base      lipgloss.Style
-	BaseFocus lipgloss.Style
-	Header    lipgloss.Style
-	Cell      lipgloss.Style
-	Selected  lipgloss.Style
-	Hovered   lipgloss.Style // Currently unused
+	Base         lipgloss.Style
+	BaseFocus    lipgloss.Style
+	Header       lipgloss.Style
+	HeaderCursor lipgloss.Style
+	ResizeHandle lipgloss.Style
+	Filter       lipgloss.Style
+	Cell         lipgloss.Style
+	Selected     lipgloss.Style // The row with the cursor
+	Marked       lipgloss.Style // Rows in the selection
+	Checkbox     lipgloss.Style
+	Hovered      lipgloss.Style // Currently unused
 }
 
 // TODO: Add this to the theme
@@ -138,6 +261,12 @@ func defaultStyles(c *app.Ctx) Styles {
 		Hovered:   lipgloss.NewStyle().Bold(true).Foreground(c.Theme.Colors.PrimaryLight).Background(c.Theme.Colors.Base600),
 		Header:    lipgloss.NewStyle().Bold(true).BorderStyle(lipgloss.NormalBorder()).BorderForeground(c.Theme.Colors.Base600).BorderBottom(true),
 		Cell:      lipgloss.NewStyle(),
+
+		HeaderCursor: lipgloss.NewStyle().Underline(true).Foreground(c.Theme.Colors.PrimaryLight),
+		ResizeHandle: lipgloss.NewStyle().Foreground(c.Theme.Colors.Base600),
+		Filter:       lipgloss.NewStyle().Foreground(c.Theme.Colors.Base300),
+		Marked:       lipgloss.NewStyle().Foreground(c.Theme.Colors.PrimaryLighter).Background(c.Theme.Colors.PrimaryDarker),
+		Checkbox:     lipgloss.NewStyle().Foreground(c.Theme.Colors.PrimaryLight),
 	}
 }
 
@@ -169,6 +298,78 @@ func WithDataFunc(f func(c *app.Ctx) (clms []Column, rows []Row)) tableProp {
 	}
 }
 
+// WithFilterFunc sets the function deciding if a row matches the filter query.
+// By default a row matches if any cell contains the query ignoring case.
+func WithFilterFunc(f func(row Row, query string) bool) tableProp {
+	return func(props *Props) {
+		props.FilterFunc = f
+	}
+}
+
+// WithCellFunc sets the function deciding how each cell is rendered.
+func WithCellFunc(f func(c *app.Ctx, row, column int, value string) Cell) tableProp {
+	return func(props *Props) {
+		props.CellFunc = f
+	}
+}
+
+// WithRowStyle sets the function giving each row its style.
+func WithRowStyle(f func(row int, data Row) lipgloss.Style) tableProp {
+	return func(props *Props) {
+		props.RowStyle = f
+	}
+}
+
+// WithMulti allows selecting more than one row.
+func WithMulti(multi bool) tableProp {
+	return func(props *Props) {
+		props.Multi = multi
+	}
+}
+
+// WithCheckbox shows a checkbox in front of each row and allows selecting
+// more than one row.
+func WithCheckbox(checkbox bool) tableProp {
+	return func(props *Props) {
+		props.Checkbox = checkbox
+		if checkbox {
+			props.Multi = true
+		}
+	}
+}
+
+// WithSelected makes the parent own the selection. The table reports changes
+// through OnSelectionChange and the parent passes the new selection back.
+func WithSelected(rows []int) tableProp {
+	return func(props *Props) {
+		if rows == nil {
+			rows = []int{}
+		}
+		props.Selected = rows
+	}
+}
+
+// WithOnCursorChange is called with the row the cursor is moved to.
+func WithOnCursorChange(onCursorChange func(row int)) tableProp {
+	return func(props *Props) {
+		props.OnCursorChange = onCursorChange
+	}
+}
+
+// WithOnSelectionChange is called with the sorted rows in the selection.
+func WithOnSelectionChange(onSelectionChange func(rows []int)) tableProp {
+	return func(props *Props) {
+		props.OnSelectionChange = onSelectionChange
+	}
+}
+
+// WithOnActivate is called when enter is pressed or a row is double clicked.
+func WithOnActivate(onActivate func(row int)) tableProp {
+	return func(props *Props) {
+		props.OnActivate = onActivate
+	}
+}
+
 // Table is the functional component for rendering a table.
 func Table(c *app.Ctx, props app.Props) string {
 	p, _ := props.(Props)
@@ -177,53 +378,268 @@ func Table(c *app.Ctx, props app.Props) string {
 	_, childHoverID := app.UseIsHovered(c)
 
 	state, setState := app.UseState(c, tableState{
-		cursor:   -1,
-		viewport: viewport.New(),
+		cursor:       -1,
+		viewport:     viewport.New(),
+		sortColumn:   -1,
+		headerCursor: -1,
+		widths:       make(map[int]int),
+		resizing:     -1,
+		selected:     make(map[int]struct{}),
+		anchor:       -1,
+		lastRow:      -1,
 	})
 
 	rawCols, rows := p.DataFunc(c)
+	view := visibleRows(rawCols, rows, state, p.FilterFunc)
+
+	width, height := app.UseSize(c)
+
+	currentBaseStyle := p.Styles.Base
+	if isFocused {
+		currentBaseStyle = p.Styles.BaseFocus
+	}
+	colsWidth := width - currentBaseStyle.GetHorizontalFrameSize() - (p.Styles.Header.GetHorizontalFrameSize() * len(rawCols))
+	if p.Checkbox {
+		colsWidth -= checkboxWidth
+	}
+	cols := columnMapping(colsWidth, rawCols, state.widths)
+
+	selected := state.selected
+	if p.Selected != nil {
+		selected = make(map[int]struct{}, len(p.Selected))
+		for _, row := range p.Selected {
+			selected[row] = struct{}{}
+		}
+	}
+
+	// setSelection stores the selection unless it is controlled and reports it.
+	setSelection := func(newState *tableState, rows map[int]struct{}) {
+		if p.Selected == nil {
+			newState.selected = rows
+		}
+		if p.OnSelectionChange != nil {
+			p.OnSelectionChange(selectedRows(rows))
+		}
+	}
+
+	// moveCursor sets the new state and reports the row the cursor moved to.
+	// When extending, the rows between the anchor and the cursor are selected.
+	moveCursor := func(extend bool) func(tableState) {
+		return func(newState tableState) {
+			if newState.cursor >= 0 && newState.cursor < len(view) {
+				if extend && p.Multi {
+					anchor := slices.Index(view, state.anchor)
+					if anchor < 0 {
+						anchor = max(state.cursor, 0)
+						newState.anchor = view[anchor]
+					}
+					setSelection(&newState, rangeSelection(view, anchor, newState.cursor))
+				} else {
+					newState.anchor = view[newState.cursor]
+				}
+			}
+			setState(newState)
+			if p.OnCursorChange != nil && newState.cursor != state.cursor && newState.cursor >= 0 && newState.cursor < len(view) {
+				p.OnCursorChange(view[newState.cursor])
+			}
+		}
+	}
+
+	toggle := func(newState tableState, position int) tableState {
+		rows := maps.Clone(selected)
+		if _, ok := rows[view[position]]; ok {
+			delete(rows, view[position])
+		} else {
+			rows[view[position]] = struct{}{}
+		}
+		newState.anchor = view[position]
+		setSelection(&newState, rows)
+		return newState
+	}
+
+	toggleAll := func() {
+		newState := state
+		rows := maps.Clone(selected)
+		if allSelected(view, selected) {
+			for _, row := range view {
+				delete(rows, row)
+			}
+		} else {
+			for _, row := range view {
+				rows[row] = struct{}{}
+			}
+		}
+		setSelection(&newState, rows)
+		setState(newState)
+	}
+
+	activate := func(position int) bool {
+		if p.OnActivate == nil || position < 0 || position >= len(view) {
+			return false
+		}
+		p.OnActivate(view[position])
+		return true
+	}
+
+	cycleSort := func(column int) {
+		if column < 0 || column >= len(rawCols) || rawCols[column].DisableSort {
+			return
+		}
+		newState := state
+		if newState.sortColumn != column {
+			newState.sortColumn = column
+			newState.sortDirection = SortAsc
+		} else {
+			newState.sortDirection = newState.sortDirection.next()
+		}
+		setState(newState)
+	}
 
 	app.UseKeyHandler(c, func(keyMsg tea.KeyMsg) bool {
-		return processInternalKeys(keyMsg, p.KeyMap, rows, state, func(t tableState) {
-			setState(t)
-		})
+		if state.filtering && processFilterKeys(keyMsg, p.KeyMap, state, setState) {
+			return true
+		}
+		switch {
+		case key.Matches(keyMsg, p.KeyMap.Filter):
+			newState := state
+			newState.filtering = true
+			setState(newState)
+			return true
+		case state.filter != "" && key.Matches(keyMsg, p.KeyMap.ClearFilter):
+			newState := state
+			newState.filter = ""
+			setState(newState)
+			return true
+		case key.Matches(keyMsg, p.KeyMap.PrevColumn):
+			if len(rawCols) == 0 {
+				return false
+			}
+			newState := state
+			newState.headerCursor = clamp(state.headerCursor-1, 0, len(rawCols)-1)
+			setState(newState)
+			return true
+		case key.Matches(keyMsg, p.KeyMap.NextColumn):
+			if len(rawCols) == 0 {
+				return false
+			}
+			newState := state
+			newState.headerCursor = clamp(state.headerCursor+1, 0, len(rawCols)-1)
+			setState(newState)
+			return true
+		case key.Matches(keyMsg, p.KeyMap.Sort):
+			cycleSort(max(state.headerCursor, 0))
+			return true
+		case key.Matches(keyMsg, p.KeyMap.Activate):
+			return activate(state.cursor)
+		case p.Multi && key.Matches(keyMsg, p.KeyMap.Toggle):
+			if state.cursor < 0 || state.cursor >= len(view) {
+				return false
+			}
+			setState(toggle(state, state.cursor))
+			return true
+		case p.Multi && key.Matches(keyMsg, p.KeyMap.SelectAll):
+			newState := state
+			rows := maps.Clone(selected)
+			for _, row := range view {
+				rows[row] = struct{}{}
+			}
+			setSelection(&newState, rows)
+			setState(newState)
+			return true
+		case p.Multi && key.Matches(keyMsg, p.KeyMap.SelectNone):
+			if len(selected) == 0 {
+				return false
+			}
+			newState := state
+			setSelection(&newState, make(map[int]struct{}))
+			setState(newState)
+			return true
+		case p.Multi && key.Matches(keyMsg, p.KeyMap.ExtendUp):
+			moveUp(state, moveCursor(true), 1, len(view))
+			return true
+		case p.Multi && key.Matches(keyMsg, p.KeyMap.ExtendDown):
+			moveDown(state, moveCursor(true), 1, len(view))
+			return true
+		}
+		return processInternalKeys(keyMsg, p.KeyMap, len(view), state, moveCursor(false))
 	})
 
 	app.UseMouseHandler(c, func(msg tea.MouseMsg, childID string) bool {
-		// TODO: Add click for headers to sort
+		if state.resizing >= 0 {
+			switch msg := msg.(type) {
+			case tea.MouseMotionMsg:
+				newState := state
+				if msg.Button != tea.MouseLeft {
+					newState.resizing = -1
+					setState(newState)
+					return false
+				}
+				newState.widths[state.resizing] = max(state.resizeStartWidth+msg.X-state.resizeStartX, 1)
+				setState(newState)
+				return true
+			case tea.MouseReleaseMsg:
+				newState := state
+				newState.resizing = -1
+				setState(newState)
+				return true
+			}
+		}
+
 		if childID == "" || msg.Mouse().Button != tea.MouseLeft {
 			return false
 		}
-		if _, ok := msg.(tea.MouseReleaseMsg); ok {
-
-			rowIDFromChild := strings.Split(childID, ":")
-			if rowIDFromChild[0] == "row" {
-				rowIndex, err := strconv.Atoi(rowIDFromChild[1])
-				if err != nil {
-					return false
+		kind, index, ok := parseChildID(childID)
+		if !ok {
+			return false
+		}
+		switch msg := msg.(type) {
+		case tea.MouseClickMsg:
+			if kind == "resize" && index < len(cols) {
+				c.FocusThis(id)
+				newState := state
+				newState.resizing = index
+				newState.resizeStartX = msg.X
+				newState.resizeStartWidth = cols[index].Width
+				setState(newState)
+				return true
+			}
+		case tea.MouseReleaseMsg:
+			switch kind {
+			case "row", "check":
+				if index < 0 || index >= len(view) {
+					return true
 				}
-				if rowIndex >= 0 && rowIndex < len(rows) {
-					state.cursor = rowIndex
-					setState(state)
+				c.FocusThis(id)
+				mod := msg.Mouse().Mod
+				newState := state
+				newState.cursor = index
+				switch {
+				case p.Multi && kind == "check", p.Multi && mod.Contains(tea.ModCtrl):
+					moveCursor(false)(toggle(newState, index))
+				case p.Multi && mod.Contains(tea.ModShift):
+					moveCursor(true)(newState)
+				case state.lastRow == view[index] && time.Since(state.lastClick) < doubleClickInterval:
+					newState.lastRow = -1
+					moveCursor(false)(newState)
+					activate(index)
+				default:
+					newState.lastRow = view[index]
+					newState.lastClick = time.Now()
+					moveCursor(false)(newState)
 				}
+			case "checkall":
+				c.FocusThis(id)
+				toggleAll()
+			case "header":
+				c.FocusThis(id)
+				cycleSort(index)
 			}
 			return true
 		}
 		return false
 	})
 
-	width, height := app.UseSize(c)
-
-	app.UseEffect(c, func() {
-		baseStyleToUse := p.Styles.Base
-		if isFocused {
-			baseStyleToUse = p.Styles.BaseFocus
-		}
-		state.cols = columnMapping(width-baseStyleToUse.GetHorizontalFrameSize()-(p.Styles.Header.GetHorizontalFrameSize()*len(rawCols)), rawCols)
-		setState(state)
-	}, []any{rawCols, width})
-
-	numRows := len(rows)
+	numRows := len(view)
 
 	// Handle cursor initialization and bounds
 	currentCursor := state.cursor
@@ -252,25 +668,67 @@ func Table(c *app.Ctx, props app.Props) string {
 		setState(newState)
 	}
 
-	headersViewStr := generateHeadersView(state.cols, p.Styles)
-
-	currentBaseStyle := p.Styles.Base
+	headerCursor := -1
 	if isFocused {
-		currentBaseStyle = p.Styles.BaseFocus
+		headerCursor = state.headerCursor
+	}
+	headersViewStr := generateHeadersView(cols, p.Styles, state.sortColumn, state.sortDirection, headerCursor, c)
+	if p.Checkbox {
+		headersViewStr = lipgloss.JoinHorizontal(lipgloss.Top, p.Styles.Header.Render(c.MouseZoneChild("checkall:0", renderCheckbox(p.Styles, headerCheck(view, selected)))), headersViewStr)
+	}
+	if state.filtering || state.filter != "" {
+		headersViewStr += "\n" + generateFilterView(state, numRows, len(rows), width-currentBaseStyle.GetHorizontalFrameSize(), p.Styles)
 	}
 
 	state.viewport.SetHeight(height - lipgloss.Height(headersViewStr) - currentBaseStyle.GetVerticalFrameSize())
 	state.viewport.SetWidth(width - currentBaseStyle.GetHorizontalFrameSize())
 
-	updateViewportContent(&state.viewport, rows, state, childHoverID, p.Styles, c, id)
+	updateViewportContent(&state.viewport, rows, view, cols, state, selected, childHoverID, p, c)
 
-	return currentBaseStyle.Render(headersViewStr + "\n" + state.viewport.View())
+	return c.MouseZone(currentBaseStyle.Render(headersViewStr + "\n" + state.viewport.View()))
 }
 
-// processInternalKeys contains the logic for handling key presses for table navigation.
-func processInternalKeys(keyMsg tea.KeyMsg, km KeyMap, rows []Row, currentTableState tableState, setState func(tableState)) bool {
-	numRows := len(rows)
+// processFilterKeys handles key presses while the filter query is being typed.
+// Navigation keys that are not text still move the cursor.
+func processFilterKeys(keyMsg tea.KeyMsg, km KeyMap, state tableState, setState func(valueOrUpdater interface{})) bool {
+	newState := state
+	switch {
+	case key.Matches(keyMsg, km.ClearFilter):
+		newState.filter = ""
+		newState.filtering = false
+	case key.Matches(keyMsg, km.AcceptFilter):
+		newState.filtering = false
+	case keyMsg.String() == "backspace":
+		runes := []rune(state.filter)
+		if len(runes) > 0 {
+			newState.filter = string(runes[:len(runes)-1])
+		}
+	case keyMsg.Key().Text != "":
+		newState.filter += keyMsg.Key().Text
+	default:
+		return false
+	}
+	newState.cursor = 0
+	newState.viewport.GotoTop()
+	setState(newState)
+	return true
+}
+
+// parseChildID splits child IDs like "row:3" into their kind and index.
+func parseChildID(childID string) (string, int, bool) {
+	kind, rawIndex, found := strings.Cut(childID, ":")
+	if !found {
+		return "", 0, false
+	}
+	index, err := strconv.Atoi(rawIndex)
+	if err != nil {
+		return "", 0, false
+	}
+	return kind, index, true
+}
 
+// processInternalKeys contains the logic for handling key presses for table navigation.
+func processInternalKeys(keyMsg tea.KeyMsg, km KeyMap, numRows int, currentTableState tableState, setState func(tableState)) bool {
 	if numRows == 0 && !(key.Matches(keyMsg, km.LineUp) || key.Matches(keyMsg, km.LineDown)) {
 		if key.Matches(keyMsg, km.LineUp) || key.Matches(keyMsg, km.LineDown) {
 			return true
@@ -382,58 +840,169 @@ func gotoBottom(currentState tableState, setState func(tableState), numRows int)
 	}
 }
 
-func generateHeadersView(cols []column, styles Styles) string {
+func generateHeadersView(cols []column, styles Styles, sortColumn int, sortDirection SortDirection, headerCursor int, c *app.Ctx) string {
 	s := make([]string, 0, len(cols))
-	for _, col := range cols {
+	for i, col := range cols {
 		if col.Width <= 0 {
 			continue
 		}
-		style := lipgloss.NewStyle().Width(col.Width).MaxWidth(col.Width)
-		renderedCell := style.Render(runewidth.Truncate(col.Title, col.Width, "…"))
-		s = append(s, styles.Header.Render(renderedCell))
+		// All columns but the last get a handle on the right edge for resizing.
+		titleWidth := col.Width
+		handle := ""
+		if i < len(cols)-1 && col.Width > 1 {
+			titleWidth--
+			handle = c.MouseZoneChild("resize:"+strconv.Itoa(i), styles.ResizeHandle.Render("│"))
+		}
+		title := col.Title
+		if i == sortColumn {
+			title += sortDirection.indicator()
+		}
+		style := lipgloss.NewStyle().Width(titleWidth).MaxWidth(titleWidth).Align(col.Align.position())
+		if i == headerCursor {
+			style = style.Inherit(styles.HeaderCursor)
+		}
+		renderedTitle := c.MouseZoneChild("header:"+strconv.Itoa(i), style.Render(runewidth.Truncate(title, titleWidth, "…")))
+		s = append(s, styles.Header.Render(renderedTitle+handle))
 	}
 	return lipgloss.JoinHorizontal(lipgloss.Top, s...)
 }
 
-func generateRenderedRow(rowIndex int, rowData Row, state tableState, childHoverID string, styles Styles, c *app.Ctx, tableID string) string {
-	rowStyle := styles.Cell
-
-	s := make([]string, 0, len(state.cols))
-	for i, value := range rowData {
-		if i >= len(state.cols) || state.cols[i].Width <= 0 {
-			continue
-		}
-		rowClmStyle := styles.Cell.Width(state.cols[i].Width).MaxWidth(state.cols[i].Width)
-		s = append(s, rowClmStyle.Render(runewidth.Truncate(value, state.cols[i].Width, "…")))
+// generateFilterView renders the filter query and the number of matching rows.
+func generateFilterView(state tableState, matches int, total int, width int, styles Styles) string {
+	query := "/" + state.filter
+	if state.filtering {
+		query += "█"
 	}
-	rowElementID := "row:" + strconv.Itoa(rowIndex)
+	count := strconv.Itoa(matches) + "/" + strconv.Itoa(total)
+	gap := max(width-lipgloss.Width(query)-lipgloss.Width(count), 1)
+	return styles.Filter.Width(width).MaxWidth(width).Render(query + strings.Repeat(" ", gap) + count)
+}
 
+func generateRenderedRow(position int, rowIndex int, rowData Row, cols []column, state tableState, marked bool, childHoverID string, p Props, c *app.Ctx) string {
+	rowElementID := "row:" + strconv.Itoa(position)
+
+	var rowStyle lipgloss.Style
 	if rowElementID == childHoverID {
-		rowStyle = rowStyle.Inherit(styles.Hovered)
-	} else if rowIndex == state.cursor {
-		rowStyle = rowStyle.Inherit(styles.Selected)
+		rowStyle = p.Styles.Hovered
+	} else if position == state.cursor {
+		rowStyle = p.Styles.Selected
+	} else if marked {
+		rowStyle = p.Styles.Marked
+	}
+	if p.RowStyle != nil {
+		rowStyle = rowStyle.Inherit(p.RowStyle(rowIndex, rowData))
 	}
+	rowStyle = rowStyle.Inherit(p.Styles.Cell)
 
-	return c.MouseZoneChild(rowElementID, rowStyle.Render(lipgloss.JoinHorizontal(lipgloss.Top, s...)))
+	s := make([]string, 0, len(cols)+1)
+	if p.Checkbox {
+		check := " "
+		if marked {
+			check = "x"
+		}
+		checkStyle := p.Styles
+		checkStyle.Checkbox = checkStyle.Checkbox.Inherit(rowStyle)
+		s = append(s, c.MouseZoneChild("check:"+strconv.Itoa(position), renderCheckbox(checkStyle, check)))
+	}
+	for i, col := range cols {
+		if col.Width <= 0 {
+			continue
+		}
+		value := cellValue(rowData, i)
+		var cell Cell
+		if p.CellFunc != nil {
+			cell = p.CellFunc(c, rowIndex, i, value)
+		}
+		s = append(s, renderCell(c, cell, value, col, rowStyle, strconv.Itoa(rowIndex)+":"+strconv.Itoa(i)))
+	}
+
+	return c.MouseZoneChild(rowElementID, lipgloss.JoinHorizontal(lipgloss.Top, s...))
 }
 
-func updateViewportContent(vp *viewport.Model, rows []Row, state tableState, childHoverID string, styles Styles, c *app.Ctx, tableID string) {
-	if len(rows) == 0 {
+// updateViewportContent sets a line for every row in view order but only
+// renders the rows inside the window of the viewport. The others are empty so
+// the viewport still scrolls over all of them while components in cells are
+// only rendered when they are seen. The row IDs are the index in the view,
+// not in the original data.
+func updateViewportContent(vp *viewport.Model, rows []Row, view []int, cols []column, state tableState, selected map[int]struct{}, childHoverID string, p Props, c *app.Ctx) {
+	if len(view) == 0 {
 		vp.SetContent("")
 		return
 	}
 
-	renderedRows := make([]string, len(rows))
-	for i, rowData := range rows {
-		renderedRows[i] = generateRenderedRow(i, rowData, state, childHoverID, styles, c, tableID)
+	// The offset is clamped to the rows before the window is taken from it.
+	renderedRows := make([]string, len(view))
+	vp.SetContent(strings.Join(renderedRows, "\n"))
+
+	start := vp.YOffset()
+	end := min(start+vp.Height(), len(view))
+	for i := start; i < end; i++ {
+		rowIndex := view[i]
+		_, marked := selected[rowIndex]
+		renderedRows[i] = generateRenderedRow(i, rowIndex, rows[rowIndex], cols, state, marked, childHoverID, p, c)
 	}
 
-	vp.SetContent(
-		lipgloss.JoinVertical(lipgloss.Left, renderedRows...),
-	)
+	vp.SetContent(strings.Join(renderedRows, "\n"))
+}
+
+// renderCheckbox renders a checkbox with the given mark in the checkbox column.
+func renderCheckbox(styles Styles, mark string) string {
+	return styles.Checkbox.Width(checkboxWidth).MaxWidth(checkboxWidth).Render("[" + mark + "]")
+}
+
+// headerCheck is the mark of the header checkbox for the visible rows.
+func headerCheck(view []int, selected map[int]struct{}) string {
+	count := 0
+	for _, row := range view {
+		if _, ok := selected[row]; ok {
+			count++
+		}
+	}
+	switch {
+	case count == 0:
+		return " "
+	case count == len(view):
+		return "x"
+	default:
+		return "-"
+	}
 }
 
-func columnMapping(width int, clms []Column) []column {
+func allSelected(view []int, selected map[int]struct{}) bool {
+	return len(view) > 0 && headerCheck(view, selected) == "x"
+}
+
+// rangeSelection selects the rows between two positions in the view.
+func rangeSelection(view []int, from, to int) map[int]struct{} {
+	if from > to {
+		from, to = to, from
+	}
+	rows := make(map[int]struct{}, to-from+1)
+	for _, row := range view[from : to+1] {
+		rows[row] = struct{}{}
+	}
+	return rows
+}
+
+func selectedRows(selected map[int]struct{}) []int {
+	rows := make([]int, 0, len(selected))
+	for row := range selected {
+		rows = append(rows, row)
+	}
+	slices.Sort(rows)
+	return rows
+}
+
+// columnMapping calculates the width of each column. Columns that have been
+// resized by the user keep their width and are no longer growing.
+func columnMapping(width int, clms []Column, resized map[int]int) []column {
+	clms = slices.Clone(clms)
+	for i, w := range resized {
+		if i < len(clms) {
+			clms[i].Width = WidthInt(w)
+		}
+	}
+
 	numberOfGrowers := 0
 	sizeOfStatic := 0
 	for _, clm := range clms {
@@ -475,6 +1044,7 @@ func columnMapping(width int, clms []Column) []column {
 		columns[i] = column{
 			Title: clm.Title,
 			Width: colWidth,
+			Align: clm.Align,
 		}
 	}
 	return columns
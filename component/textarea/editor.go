@@ -0,0 +1,454 @@
+package textarea
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// maxHistory is the number of edits that can be undone.
+const maxHistory = 100
+
+// position is a place in the text as a line and a rune in that line.
+type position struct {
+	line int
+	col  int
+}
+
+func (p position) before(o position) bool {
+	return p.line < o.line || (p.line == o.line && p.col < o.col)
+}
+
+// segment is the part of a line shown on one row after soft wrapping.
+type segment struct {
+	line  int
+	start int
+	end   int
+	last  bool // The last segment of the line
+}
+
+type editKind int
+
+const (
+	editNone editKind = iota
+	editInsert
+	editDelete
+	editOther
+)
+
+// snapshot is the text, cursor and selection before an edit.
+type snapshot struct {
+	lines     [][]rune
+	cursor    position
+	anchor    position
+	selecting bool
+}
+
+// editor holds the text being edited with the cursor, the selection and the
+// undo history.
+type editor struct {
+	lines  [][]rune
+	cursor position
+	// anchor is where the selection started. The selection is between the
+	// anchor and the cursor.
+	anchor    position
+	selecting bool
+	// goalX is the column the cursor tries to stay in when moving up and down
+	// or -1 when it has not moved up or down yet.
+	goalX int
+
+	undo     []snapshot
+	redo     []snapshot
+	lastEdit editKind
+
+	// segments are the rows of the last render.
+	segments []segment
+}
+
+func newEditor(value string) editor {
+	return editor{lines: splitLines(value), goalX: -1}
+}
+
+func splitLines(value string) [][]rune {
+	value = strings.ReplaceAll(value, "\r\n", "\n")
+	split := strings.Split(value, "\n")
+	lines := make([][]rune, len(split))
+	for i, line := range split {
+		lines[i] = []rune(line)
+	}
+	return lines
+}
+
+func (e *editor) text() string {
+	s := make([]string, len(e.lines))
+	for i, line := range e.lines {
+		s[i] = string(line)
+	}
+	return strings.Join(s, "\n")
+}
+
+func (e *editor) empty() bool {
+	return len(e.lines) == 1 && len(e.lines[0]) == 0
+}
+
+// setText replaces the text without touching the undo history. The cursor is
+// kept where it is when possible.
+func (e *editor) setText(value string) {
+	e.lines = splitLines(value)
+	e.cursor = e.clamp(e.cursor)
+	e.selecting = false
+	e.lastEdit = editNone
+}
+
+func (e *editor) clamp(p position) position {
+	p.line = max(0, min(p.line, len(e.lines)-1))
+	p.col = max(0, min(p.col, len(e.lines[p.line])))
+	return p
+}
+
+// wrap splits the lines into rows no wider than width. Lines are broken
+// after the last space that fits or inside a word when it does not fit.
+func (e *editor) wrap(width int) []segment {
+	width = max(1, width)
+	segments := []segment{}
+	for i, line := range e.lines {
+		start := 0
+		for {
+			w, end, space := 0, start, -1
+			for end < len(line) {
+				rw := runewidth.RuneWidth(line[end])
+				if w+rw > width {
+					break
+				}
+				if line[end] == ' ' {
+					space = end
+				}
+				w += rw
+				end++
+			}
+			if end >= len(line) {
+				segments = append(segments, segment{line: i, start: start, end: len(line), last: true})
+				break
+			}
+			if space >= start {
+				end = space + 1
+			}
+			if end == start {
+				end = start + 1
+			}
+			segments = append(segments, segment{line: i, start: start, end: end})
+			start = end
+		}
+	}
+	e.segments = segments
+	return segments
+}
+
+// row returns the index of the segment showing the position.
+func (e *editor) row(p position) int {
+	for i, s := range e.segments {
+		if s.line == p.line && p.col >= s.start && (p.col < s.end || s.last) {
+			return i
+		}
+	}
+	return 0
+}
+
+// x returns the column of the position on its row.
+func (e *editor) x(p position) int {
+	s := e.segments[e.row(p)]
+	return runewidth.StringWidth(string(e.lines[p.line][s.start:p.col]))
+}
+
+// colAt returns the position on the row closest to the column x.
+func (e *editor) colAt(row int, x int) position {
+	s := e.segments[row]
+	last := s.end
+	if !s.last {
+		last--
+	}
+	col, w := s.start, 0
+	for col < last {
+		rw := runewidth.RuneWidth(e.lines[s.line][col])
+		if w+rw > x {
+			break
+		}
+		w += rw
+		col++
+	}
+	return position{line: s.line, col: col}
+}
+
+// selection returns the selected range ordered from start to end.
+func (e *editor) selection() (position, position, bool) {
+	if !e.selecting || e.anchor == e.cursor {
+		return e.cursor, e.cursor, false
+	}
+	if e.anchor.before(e.cursor) {
+		return e.anchor, e.cursor, true
+	}
+	return e.cursor, e.anchor, true
+}
+
+func (e *editor) selectedText() string {
+	from, to, ok := e.selection()
+	if !ok {
+		return ""
+	}
+	if from.line == to.line {
+		return string(e.lines[from.line][from.col:to.col])
+	}
+	s := []string{string(e.lines[from.line][from.col:])}
+	for i := from.line + 1; i < to.line; i++ {
+		s = append(s, string(e.lines[i]))
+	}
+	s = append(s, string(e.lines[to.line][:to.col]))
+	return strings.Join(s, "\n")
+}
+
+// move moves the cursor. With extend the selection is extended to the new
+// position, otherwise it is cleared.
+func (e *editor) move(p position, extend bool) {
+	if extend && !e.selecting {
+		e.anchor = e.cursor
+		e.selecting = true
+	} else if !extend {
+		e.selecting = false
+	}
+	e.cursor = e.clamp(p)
+	e.lastEdit = editNone
+}
+
+func (e *editor) left(extend bool) {
+	e.goalX = -1
+	if from, _, ok := e.selection(); ok && !extend {
+		e.move(from, false)
+		return
+	}
+	p := e.cursor
+	if p.col > 0 {
+		p.col--
+	} else if p.line > 0 {
+		p.line--
+		p.col = len(e.lines[p.line])
+	}
+	e.move(p, extend)
+}
+
+func (e *editor) right(extend bool) {
+	e.goalX = -1
+	if _, to, ok := e.selection(); ok && !extend {
+		e.move(to, false)
+		return
+	}
+	p := e.cursor
+	if p.col < len(e.lines[p.line]) {
+		p.col++
+	} else if p.line < len(e.lines)-1 {
+		p.line++
+		p.col = 0
+	}
+	e.move(p, extend)
+}
+
+// vertical moves the cursor up or down by rows. Moving past the first or the
+// last row moves to the start or the end of the text.
+func (e *editor) vertical(rows int, extend bool) {
+	if len(e.segments) == 0 {
+		return
+	}
+	if e.goalX < 0 {
+		e.goalX = e.x(e.cursor)
+	}
+	row := e.row(e.cursor) + rows
+	switch {
+	case row < 0:
+		e.top(extend)
+	case row >= len(e.segments):
+		e.bottom(extend)
+	default:
+		e.move(e.colAt(row, e.goalX), extend)
+	}
+}
+
+func (e *editor) wordLeft(extend bool) {
+	e.goalX = -1
+	p := e.cursor
+	if p.col == 0 {
+		e.left(extend)
+		return
+	}
+	line := e.lines[p.line]
+	for p.col > 0 && unicode.IsSpace(line[p.col-1]) {
+		p.col--
+	}
+	for p.col > 0 && !unicode.IsSpace(line[p.col-1]) {
+		p.col--
+	}
+	e.move(p, extend)
+}
+
+func (e *editor) wordRight(extend bool) {
+	e.goalX = -1
+	p := e.cursor
+	line := e.lines[p.line]
+	if p.col == len(line) {
+		e.right(extend)
+		return
+	}
+	for p.col < len(line) && unicode.IsSpace(line[p.col]) {
+		p.col++
+	}
+	for p.col < len(line) && !unicode.IsSpace(line[p.col]) {
+		p.col++
+	}
+	e.move(p, extend)
+}
+
+// rowStart and rowEnd move to the start and the end of the row with the
+// cursor.
+func (e *editor) rowStart(extend bool) {
+	e.goalX = -1
+	s := e.segments[e.row(e.cursor)]
+	e.move(position{line: s.line, col: s.start}, extend)
+}
+
+func (e *editor) rowEnd(extend bool) {
+	e.goalX = -1
+	s := e.segments[e.row(e.cursor)]
+	col := s.end
+	if !s.last {
+		col--
+	}
+	e.move(position{line: s.line, col: col}, extend)
+}
+
+func (e *editor) top(extend bool) {
+	e.goalX = -1
+	e.move(position{}, extend)
+}
+
+func (e *editor) bottom(extend bool) {
+	e.goalX = -1
+	last := len(e.lines) - 1
+	e.move(position{line: last, col: len(e.lines[last])}, extend)
+}
+
+func (e *editor) selectAll() {
+	last := len(e.lines) - 1
+	e.anchor = position{}
+	e.cursor = position{line: last, col: len(e.lines[last])}
+	e.selecting = true
+	e.goalX = -1
+	e.lastEdit = editNone
+}
+
+// record saves the text before an edit so it can be undone. Edits of the same
+// kind following each other are undone together.
+func (e *editor) record(kind editKind) {
+	if kind == editOther || kind != e.lastEdit {
+		e.undo = append(e.undo, e.snapshot())
+		if len(e.undo) > maxHistory {
+			e.undo = e.undo[1:]
+		}
+	}
+	e.redo = nil
+	e.lastEdit = kind
+	e.goalX = -1
+}
+
+func (e *editor) snapshot() snapshot {
+	lines := make([][]rune, len(e.lines))
+	for i, line := range e.lines {
+		lines[i] = append([]rune(nil), line...)
+	}
+	return snapshot{lines: lines, cursor: e.cursor, anchor: e.anchor, selecting: e.selecting}
+}
+
+func (e *editor) restore(s snapshot) {
+	e.lines = s.lines
+	e.cursor, e.anchor, e.selecting = s.cursor, s.anchor, s.selecting
+	e.goalX = -1
+	e.lastEdit = editNone
+}
+
+func (e *editor) undoEdit() bool {
+	if len(e.undo) == 0 {
+		return false
+	}
+	e.redo = append(e.redo, e.snapshot())
+	e.restore(e.undo[len(e.undo)-1])
+	e.undo = e.undo[:len(e.undo)-1]
+	return true
+}
+
+func (e *editor) redoEdit() bool {
+	if len(e.redo) == 0 {
+		return false
+	}
+	e.undo = append(e.undo, e.snapshot())
+	e.restore(e.redo[len(e.redo)-1])
+	e.redo = e.redo[:len(e.redo)-1]
+	return true
+}
+
+// deleteRange removes the text between from and to and puts the cursor there.
+func (e *editor) deleteRange(from, to position) {
+	line := append(e.lines[from.line][:from.col:from.col], e.lines[to.line][to.col:]...)
+	e.lines = append(e.lines[:from.line+1], e.lines[to.line+1:]...)
+	e.lines[from.line] = line
+	e.cursor = from
+	e.selecting = false
+}
+
+// insert replaces the selection with the text.
+func (e *editor) insert(text string, kind editKind) {
+	e.record(kind)
+	if from, to, ok := e.selection(); ok {
+		e.deleteRange(from, to)
+	}
+	inserted := splitLines(text)
+	line := e.lines[e.cursor.line]
+	head := append([]rune(nil), line[:e.cursor.col]...)
+	tail := append([]rune(nil), line[e.cursor.col:]...)
+
+	lines := make([][]rune, 0, len(e.lines)+len(inserted)-1)
+	lines = append(lines, e.lines[:e.cursor.line]...)
+	for i, l := range inserted {
+		if i == 0 {
+			l = append(head, l...)
+		}
+		lines = append(lines, l)
+	}
+	last := len(lines) - 1
+	col := len(lines[last])
+	lines[last] = append(lines[last], tail...)
+	lines = append(lines, e.lines[e.cursor.line+1:]...)
+
+	e.lines = lines
+	e.cursor = position{line: last, col: col}
+	e.selecting = false
+}
+
+// deleteTo deletes the selection or, without one, the text between the
+// cursor and where move would move it.
+func (e *editor) deleteTo(move func(extend bool)) bool {
+	from, to, ok := e.selection()
+	if !ok {
+		saved := *e
+		move(false)
+		target := e.cursor
+		*e = saved
+		if target == e.cursor {
+			return false
+		}
+		from, to = target, e.cursor
+		if to.before(from) {
+			from, to = to, from
+		}
+	}
+	e.record(editDelete)
+	e.deleteRange(from, to)
+	return true
+}
@@ -0,0 +1,458 @@
+package textarea
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/style"
+
+	"github.com/charmbracelet/bubbles/v2/key"
+	"github.com/charmbracelet/bubbles/v2/viewport"
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// Props holds the configuration for the TextArea component.
+type Props struct {
+	Value       string
+	Placeholder string
+	// ShowLineNumbers shows the number of each line in a gutter to the left.
+	// Soft wrapped rows have no number.
+	ShowLineNumbers bool
+	OnChange        func(text string)
+	KeyMap          KeyMap
+	Styles          style.TextAreaTheme
+	app.Layout
+}
+
+type prop func(*Props)
+
+// textAreaState holds the internal state of the TextArea component.
+type textAreaState struct {
+	editor   editor
+	viewport viewport.Model
+	value    string // The value last received or sent through OnChange
+	follow   bool   // Scroll to the cursor on the next render
+	dragging bool
+}
+
+// scrollStep is the number of rows scrolled by the mouse wheel.
+const scrollStep = 3
+
+type KeyMap struct {
+	Left               key.Binding
+	Right              key.Binding
+	Up                 key.Binding
+	Down               key.Binding
+	WordLeft           key.Binding
+	WordRight          key.Binding
+	LineStart          key.Binding
+	LineEnd            key.Binding
+	PageUp             key.Binding
+	PageDown           key.Binding
+	Top                key.Binding
+	Bottom             key.Binding
+	SelectLeft         key.Binding
+	SelectRight        key.Binding
+	SelectUp           key.Binding
+	SelectDown         key.Binding
+	SelectWordLeft     key.Binding
+	SelectWordRight    key.Binding
+	SelectLineStart    key.Binding
+	SelectLineEnd      key.Binding
+	SelectAll          key.Binding
+	InsertNewline      key.Binding
+	DeleteBackward     key.Binding
+	DeleteForward      key.Binding
+	DeleteWordBackward key.Binding
+	DeleteWordForward  key.Binding
+	Undo               key.Binding
+	Redo               key.Binding
+	// Copy and Cut only handle the key when there is a selection.
+	Copy  key.Binding
+	Cut   key.Binding
+	Paste key.Binding
+}
+
+func (km KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{km.SelectLeft, km.SelectAll, km.Undo, km.Redo}
+}
+
+func (km KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{km.Left, km.Right, km.Up, km.Down, km.WordLeft, km.WordRight},
+		{km.LineStart, km.LineEnd, km.PageUp, km.PageDown, km.Top, km.Bottom},
+		{km.SelectLeft, km.SelectRight, km.SelectUp, km.SelectDown, km.SelectAll},
+		{km.InsertNewline, km.DeleteBackward, km.DeleteForward, km.DeleteWordBackward, km.Undo, km.Redo},
+		{km.Copy, km.Cut, km.Paste},
+	}
+}
+
+func defaultKeyMap() KeyMap {
+	return KeyMap{
+		Left:               key.NewBinding(key.WithKeys("left", "ctrl+b"), key.WithHelp("←", "left")),
+		Right:              key.NewBinding(key.WithKeys("right", "ctrl+f"), key.WithHelp("→", "right")),
+		Up:                 key.NewBinding(key.WithKeys("up", "ctrl+p"), key.WithHelp("↑", "up")),
+		Down:               key.NewBinding(key.WithKeys("down", "ctrl+n"), key.WithHelp("↓", "down")),
+		WordLeft:           key.NewBinding(key.WithKeys("alt+left", "ctrl+left", "alt+b"), key.WithHelp("alt+←", "word left")),
+		WordRight:          key.NewBinding(key.WithKeys("alt+right", "ctrl+right", "alt+f"), key.WithHelp("alt+→", "word right")),
+		LineStart:          key.NewBinding(key.WithKeys("home"), key.WithHelp("home", "line start")),
+		LineEnd:            key.NewBinding(key.WithKeys("end", "ctrl+e"), key.WithHelp("end", "line end")),
+		PageUp:             key.NewBinding(key.WithKeys("pgup"), key.WithHelp("pgup", "page up")),
+		PageDown:           key.NewBinding(key.WithKeys("pgdown"), key.WithHelp("pgdn", "page down")),
+		Top:                key.NewBinding(key.WithKeys("ctrl+home"), key.WithHelp("ctrl+home", "go to start")),
+		Bottom:             key.NewBinding(key.WithKeys("ctrl+end"), key.WithHelp("ctrl+end", "go to end")),
+		SelectLeft:         key.NewBinding(key.WithKeys("shift+left"), key.WithHelp("shift+arrows", "select")),
+		SelectRight:        key.NewBinding(key.WithKeys("shift+right"), key.WithHelp("shift+→", "select right")),
+		SelectUp:           key.NewBinding(key.WithKeys("shift+up"), key.WithHelp("shift+↑", "select up")),
+		SelectDown:         key.NewBinding(key.WithKeys("shift+down"), key.WithHelp("shift+↓", "select down")),
+		SelectWordLeft:     key.NewBinding(key.WithKeys("alt+shift+left", "ctrl+shift+left"), key.WithHelp("alt+shift+←", "select word left")),
+		SelectWordRight:    key.NewBinding(key.WithKeys("alt+shift+right", "ctrl+shift+right"), key.WithHelp("alt+shift+→", "select word right")),
+		SelectLineStart:    key.NewBinding(key.WithKeys("shift+home"), key.WithHelp("shift+home", "select to line start")),
+		SelectLineEnd:      key.NewBinding(key.WithKeys("shift+end"), key.WithHelp("shift+end", "select to line end")),
+		SelectAll:          key.NewBinding(key.WithKeys("ctrl+a"), key.WithHelp("ctrl+a", "select all")),
+		InsertNewline:      key.NewBinding(key.WithKeys("enter", "ctrl+m"), key.WithHelp("enter", "new line")),
+		DeleteBackward:     key.NewBinding(key.WithKeys("backspace", "ctrl+h"), key.WithHelp("backspace", "delete")),
+		DeleteForward:      key.NewBinding(key.WithKeys("delete", "ctrl+d"), key.WithHelp("delete", "delete forward")),
+		DeleteWordBackward: key.NewBinding(key.WithKeys("alt+backspace", "ctrl+w"), key.WithHelp("ctrl+w", "delete word")),
+		DeleteWordForward:  key.NewBinding(key.WithKeys("alt+delete", "alt+d"), key.WithHelp("alt+d", "delete word forward")),
+		Undo:               key.NewBinding(key.WithKeys("ctrl+z"), key.WithHelp("ctrl+z", "undo")),
+		Redo:               key.NewBinding(key.WithKeys("ctrl+y", "ctrl+shift+z"), key.WithHelp("ctrl+y", "redo")),
+		Copy:               key.NewBinding(key.WithKeys("ctrl+c"), key.WithHelp("ctrl+c", "copy")),
+		Cut:                key.NewBinding(key.WithKeys("ctrl+x"), key.WithHelp("ctrl+x", "cut")),
+		Paste:              key.NewBinding(key.WithKeys("ctrl+v"), key.WithHelp("ctrl+v", "paste")),
+	}
+}
+
+// TextArea is a multi-line text input. Long lines are soft wrapped to the
+// width of the component and the text scrolls to keep the cursor in view.
+func TextArea(c *app.Ctx, rawProps app.Props) string {
+	props, ok := rawProps.(Props)
+	if !ok {
+		panic("TextArea: props must be of type textarea.Props")
+	}
+
+	id := app.UseID(c)
+	focused := app.UseIsFocused(c)
+	clipboard := app.UseClipboard(c)
+
+	state, _ := app.UseState(c, &textAreaState{
+		editor:   newEditor(props.Value),
+		viewport: viewport.New(),
+		value:    props.Value,
+	})
+	e := &state.editor
+
+	app.UseEffect(c, func() {
+		if props.Value != state.value {
+			state.value = props.Value
+			e.setText(props.Value)
+		}
+	}, []any{props.Value})
+
+	width, height := app.UseSize(c)
+	if !props.GrowY && props.Height > 0 {
+		height = props.Height
+	}
+	x, y := app.UseGlobalPosition(c)
+
+	baseStyle := props.Styles.Base
+	if focused {
+		baseStyle = props.Styles.BaseFocus
+	}
+	frameLeft := baseStyle.GetBorderLeftSize() + baseStyle.GetPaddingLeft()
+	frameTop := baseStyle.GetBorderTopSize() + baseStyle.GetPaddingTop()
+	contentWidth := max(0, width-baseStyle.GetHorizontalFrameSize())
+	contentHeight := max(1, height-baseStyle.GetVerticalFrameSize())
+
+	gutterWidth := 0
+	digits := 0
+	if props.ShowLineNumbers {
+		digits = max(2, len(strconv.Itoa(len(e.lines))))
+		gutterWidth = digits + 1
+	}
+	// One column is kept free for the cursor at the end of a full row.
+	segments := e.wrap(contentWidth - gutterWidth - 1)
+	cursorRow := e.row(e.cursor)
+
+	// The viewport is only kept from the last phase as the size in the other
+	// phases would reset the scroll position.
+	vp := state.viewport
+	vp.SetWidth(contentWidth)
+	vp.SetHeight(contentHeight)
+	vp.FillHeight = true
+	vp.LeftGutterFunc = viewport.NoGutter
+	if props.ShowLineNumbers {
+		vp.LeftGutterFunc = func(info viewport.GutterContext) string {
+			if info.Index >= len(segments) || segments[info.Index].start > 0 {
+				return strings.Repeat(" ", gutterWidth)
+			}
+			line := segments[info.Index].line
+			numberStyle := props.Styles.LineNumber
+			if line == e.cursor.line {
+				numberStyle = props.Styles.CursorLineNumber
+			}
+			return numberStyle.Render(fmt.Sprintf("%*d", digits, line+1)) + " "
+		}
+	}
+	if e.empty() && props.Placeholder != "" {
+		vp.SetContentLines([]string{props.Styles.Placeholder.Render(props.Placeholder)})
+	} else {
+		vp.SetContentLines(renderRows(e, props.Styles))
+	}
+	if c.LayoutPhase == app.LayoutPhaseFinalRender {
+		if state.follow {
+			state.follow = false
+			if cursorRow < vp.YOffset() {
+				vp.SetYOffset(cursorRow)
+			} else if cursorRow >= vp.YOffset()+contentHeight {
+				vp.SetYOffset(cursorRow - contentHeight + 1)
+			}
+		}
+		state.viewport = vp
+	}
+
+	changed := func() {
+		state.follow = true
+		if text := e.text(); text != state.value {
+			state.value = text
+			if props.OnChange != nil {
+				props.OnChange(text)
+			}
+		}
+		c.Update()
+	}
+
+	app.UseKeyHandler(c, func(keyMsg tea.KeyMsg) bool {
+		km := props.KeyMap
+		switch {
+		case key.Matches(keyMsg, km.Left):
+			e.left(false)
+		case key.Matches(keyMsg, km.Right):
+			e.right(false)
+		case key.Matches(keyMsg, km.Up):
+			e.vertical(-1, false)
+		case key.Matches(keyMsg, km.Down):
+			e.vertical(1, false)
+		case key.Matches(keyMsg, km.WordLeft):
+			e.wordLeft(false)
+		case key.Matches(keyMsg, km.WordRight):
+			e.wordRight(false)
+		case key.Matches(keyMsg, km.LineStart):
+			e.rowStart(false)
+		case key.Matches(keyMsg, km.LineEnd):
+			e.rowEnd(false)
+		case key.Matches(keyMsg, km.PageUp):
+			e.vertical(-contentHeight, false)
+		case key.Matches(keyMsg, km.PageDown):
+			e.vertical(contentHeight, false)
+		case key.Matches(keyMsg, km.Top):
+			e.top(false)
+		case key.Matches(keyMsg, km.Bottom):
+			e.bottom(false)
+		case key.Matches(keyMsg, km.SelectLeft):
+			e.left(true)
+		case key.Matches(keyMsg, km.SelectRight):
+			e.right(true)
+		case key.Matches(keyMsg, km.SelectUp):
+			e.vertical(-1, true)
+		case key.Matches(keyMsg, km.SelectDown):
+			e.vertical(1, true)
+		case key.Matches(keyMsg, km.SelectWordLeft):
+			e.wordLeft(true)
+		case key.Matches(keyMsg, km.SelectWordRight):
+			e.wordRight(true)
+		case key.Matches(keyMsg, km.SelectLineStart):
+			e.rowStart(true)
+		case key.Matches(keyMsg, km.SelectLineEnd):
+			e.rowEnd(true)
+		case key.Matches(keyMsg, km.SelectAll):
+			e.selectAll()
+		case key.Matches(keyMsg, km.InsertNewline):
+			e.insert("\n", editOther)
+		case key.Matches(keyMsg, km.DeleteBackward):
+			e.deleteTo(e.left)
+		case key.Matches(keyMsg, km.DeleteForward):
+			e.deleteTo(e.right)
+		case key.Matches(keyMsg, km.DeleteWordBackward):
+			e.deleteTo(e.wordLeft)
+		case key.Matches(keyMsg, km.DeleteWordForward):
+			e.deleteTo(e.wordRight)
+		case key.Matches(keyMsg, km.Copy, km.Cut):
+			text := e.selectedText()
+			if text == "" {
+				return false
+			}
+			clipboard.Copy(text)
+			if key.Matches(keyMsg, km.Cut) {
+				e.deleteTo(e.left)
+			}
+		case key.Matches(keyMsg, km.Paste):
+			clipboard.Paste()
+			return true
+		case key.Matches(keyMsg, km.Undo):
+			e.undoEdit()
+		case key.Matches(keyMsg, km.Redo):
+			e.redoEdit()
+		case keyMsg.Key().Text != "" && keyMsg.String() != "tab":
+			e.insert(keyMsg.Key().Text, editInsert)
+		default:
+			return false
+		}
+		changed()
+		return true
+	})
+
+	app.UseMsgHandler(c, func(msg tea.Msg) tea.Cmd {
+		switch msg := msg.(type) {
+		case tea.PasteMsg:
+			e.insert(string(msg), editOther)
+			changed()
+		case tea.ClipboardMsg:
+			e.insert(string(msg), editOther)
+			changed()
+		}
+		return nil
+	})
+
+	// positionAt returns the position in the text below the mouse.
+	positionAt := func(mouse tea.Mouse) position {
+		row := max(0, min(mouse.Y-y-frameTop+state.viewport.YOffset(), len(segments)-1))
+		return e.colAt(row, mouse.X-x-frameLeft-gutterWidth)
+	}
+
+	app.UseMouseHandler(c, func(msg tea.MouseMsg, childID string) bool {
+		mouse := msg.Mouse()
+		switch msg.(type) {
+		case tea.MouseClickMsg:
+			if mouse.Button != tea.MouseLeft {
+				return false
+			}
+			c.FocusThis(id)
+			e.goalX = -1
+			e.move(positionAt(mouse), mouse.Mod.Contains(tea.ModShift))
+			state.dragging = true
+		case tea.MouseMotionMsg:
+			if !state.dragging || mouse.Button != tea.MouseLeft {
+				return false
+			}
+			e.move(positionAt(mouse), true)
+			state.follow = true
+		case tea.MouseReleaseMsg:
+			if mouse.Button != tea.MouseLeft {
+				return false
+			}
+			state.dragging = false
+		case tea.MouseWheelMsg:
+			switch mouse.Button {
+			case tea.MouseWheelUp:
+				state.viewport.ScrollUp(scrollStep)
+			case tea.MouseWheelDown:
+				state.viewport.ScrollDown(scrollStep)
+			default:
+				return false
+			}
+		default:
+			return false
+		}
+		c.Update()
+		return true
+	})
+
+	if row := cursorRow - vp.YOffset(); row >= 0 && row < contentHeight {
+		app.UseCursor(c, tea.NewCursor(gutterWidth+e.x(e.cursor), row), frameLeft, frameTop)
+	}
+
+	return c.MouseZone(baseStyle.Render(vp.View()))
+}
+
+// renderRows renders each segment of the text with the selection highlighted.
+func renderRows(e *editor, styles style.TextAreaTheme) []string {
+	from, to, selecting := e.selection()
+	rows := make([]string, len(e.segments))
+	for i, s := range e.segments {
+		line := e.lines[s.line]
+		if !selecting || s.line < from.line || s.line > to.line {
+			rows[i] = styles.Text.Render(string(line[s.start:s.end]))
+			continue
+		}
+		start, end := s.start, s.end
+		if s.line == from.line {
+			start = max(start, min(from.col, s.end))
+		}
+		if s.line == to.line {
+			end = min(end, max(to.col, s.start))
+		}
+		end = max(start, end)
+		selected := styles.Selection.Render(string(line[start:end]))
+		// The line break of a selected line is shown as a selected space.
+		if s.last && s.line < to.line {
+			selected += styles.Selection.Render(" ")
+		}
+		rows[i] = styles.Text.Render(string(line[s.start:start])) + selected + styles.Text.Render(string(line[end:s.end]))
+	}
+	return rows
+}
+
+// New creates a multi-line text area. onChange is called with the whole text
+// after every edit.
+func New(c *app.Ctx, onChange func(text string), value string, opts ...prop) *app.C {
+	p := Props{
+		Value:    value,
+		OnChange: onChange,
+		KeyMap:   defaultKeyMap(),
+		Styles:   c.Theme.TextArea,
+		Layout:   app.Layout{GrowX: true, Height: 8},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&p)
+		}
+	}
+	return c.Render(TextArea, p)
+}
+
+func WithPlaceholder(placeholder string) prop {
+	return func(p *Props) {
+		p.Placeholder = placeholder
+	}
+}
+
+// WithLineNumbers shows the line numbers in a gutter to the left of the text.
+func WithLineNumbers(show bool) prop {
+	return func(p *Props) {
+		p.ShowLineNumbers = show
+	}
+}
+
+func WithKeyMap(keyMap KeyMap) prop {
+	return func(p *Props) {
+		p.KeyMap = keyMap
+	}
+}
+
+func WithStyles(styles style.TextAreaTheme) prop {
+	return func(p *Props) {
+		p.Styles = styles
+	}
+}
+
+// WithHeight sets the height of the text area including the border.
+func WithHeight(height int) prop {
+	return func(p *Props) {
+		p.Layout.Height = height
+	}
+}
+
+func WithGrowX(grow bool) prop {
+	return func(p *Props) {
+		p.Layout.GrowX = grow
+	}
+}
+
+func WithGrowY(grow bool) prop {
+	return func(p *Props) {
+		p.Layout.GrowY = grow
+	}
+}
@@ -4,26 +4,89 @@ import (
 	"image/color"
 
 	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/style"
 
-	"github.com/alexanderbh/bubbleapp/component/textfield/internal/textinput"
+	"github.com/alexanderbh/bubbleapp/component/internal/textinput"
+	"github.com/charmbracelet/bubbles/v2/key"
+	bubblestextinput "github.com/charmbracelet/bubbles/v2/textinput"
 	"github.com/charmbracelet/lipgloss/v2"
 
 	tea "github.com/charmbracelet/bubbletea/v2"
 )
 
+// EchoMode decides how the typed text is shown.
+type EchoMode int
+
+const (
+	// EchoNormal shows the text as it is.
+	EchoNormal EchoMode = iota
+	// EchoPassword shows the EchoCharacter in place of each character.
+	EchoPassword
+	// EchoNone shows nothing while typing.
+	EchoNone
+)
+
 type Props struct {
-	Title      string
-	Value      string
+	Title       string
+	Value       string
+	Placeholder string
+	EchoMode    EchoMode
+	// EchoCharacter is shown for each character in EchoPassword mode.
+	EchoCharacter rune
+	// CharLimit is the most characters the field accepts. 0 is no limit.
+	CharLimit int
+	// Validate checks the value once it has been edited. The message of the
+	// error is shown below the field.
+	Validate func(text string) error
+	// Suggestions are offered as completions of the typed text. The rest of
+	// the first match is shown after the cursor.
+	Suggestions []string
+	// OnPaste is given pasted text and returns the text to insert. Return ""
+	// to ignore the paste.
+	OnPaste func(text string) string
+	// ReadOnly allows focusing and moving the cursor but not editing.
+	ReadOnly   bool
+	Disabled   bool
 	Foreground color.Color
 	Background color.Color
 	Bold       bool
 	OnChange   func(text string)
 	OnEnter    func()
+	KeyMap     KeyMap
 	app.Margin
 	app.Padding
 	app.Layout
 }
 
+type KeyMap struct {
+	// AcceptSuggestion completes the text with the suggestion. Tab moves the
+	// focus as usual when there is nothing to complete.
+	AcceptSuggestion key.Binding
+	NextSuggestion   key.Binding
+	PrevSuggestion   key.Binding
+	// Copy puts the whole text on the clipboard unless it is masked.
+	Copy  key.Binding
+	Paste key.Binding
+}
+
+func (km KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{km.AcceptSuggestion, km.NextSuggestion, km.PrevSuggestion, km.Copy, km.Paste}
+}
+
+func (km KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{km.ShortHelp()}
+}
+
+func defaultKeyMap() KeyMap {
+	return KeyMap{
+		AcceptSuggestion: key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "accept suggestion")),
+		NextSuggestion:   key.NewBinding(key.WithKeys("down", "ctrl+n"), key.WithHelp("↓", "next suggestion")),
+		PrevSuggestion:   key.NewBinding(key.WithKeys("up", "ctrl+p"), key.WithHelp("↑", "previous suggestion")),
+		Copy:             key.NewBinding(key.WithKeys("alt+c"), key.WithHelp("alt+c", "copy")),
+		Paste:            key.NewBinding(key.WithKeys("ctrl+v"), key.WithHelp("ctrl+v", "paste")),
+	}
+}
+
 type prop func(*Props)
 
 func WithTitle(title string) prop {
@@ -62,19 +125,84 @@ func WithOnEnter(onEnter func()) prop {
 		p.OnEnter = onEnter
 	}
 }
+func WithPlaceholder(placeholder string) prop {
+	return func(p *Props) {
+		p.Placeholder = placeholder
+	}
+}
 
-// Text is the core functional component for rendering text.
+// WithEchoMode hides the typed text. Use EchoPassword for passwords.
+func WithEchoMode(mode EchoMode) prop {
+	return func(p *Props) {
+		p.EchoMode = mode
+	}
+}
+func WithEchoCharacter(char rune) prop {
+	return func(p *Props) {
+		p.EchoCharacter = char
+	}
+}
+func WithCharLimit(limit int) prop {
+	return func(p *Props) {
+		p.CharLimit = limit
+	}
+}
+
+// WithValidate shows the message of the error returned by validate below the
+// field once the value has been edited.
+func WithValidate(validate func(text string) error) prop {
+	return func(p *Props) {
+		p.Validate = validate
+	}
+}
+
+// WithSuggestions offers completions for the typed text. They are accepted
+// with the AcceptSuggestion key.
+func WithSuggestions(suggestions []string) prop {
+	return func(p *Props) {
+		p.Suggestions = suggestions
+	}
+}
+
+// WithOnPaste sets the function deciding what is inserted when text is
+// pasted.
+func WithOnPaste(onPaste func(text string) string) prop {
+	return func(p *Props) {
+		p.OnPaste = onPaste
+	}
+}
+func WithReadOnly(readOnly bool) prop {
+	return func(p *Props) {
+		p.ReadOnly = readOnly
+	}
+}
+func WithDisabled(disabled bool) prop {
+	return func(p *Props) {
+		p.Disabled = disabled
+	}
+}
+func WithKeyMap(keyMap KeyMap) prop {
+	return func(p *Props) {
+		p.KeyMap = keyMap
+	}
+}
+
+// TextField is a single line text input with an optional title above it and
+// the validation error below it.
 func TextField(c *app.Ctx, rawProps app.Props) string {
 	props, ok := rawProps.(Props)
 	if !ok {
 		panic("TextField: incorrect props type")
 	}
 
-	focused := app.UseIsFocused(c)
+	// A disabled field can not be focused.
+	focused := !props.Disabled && app.UseIsFocused(c)
 
 	t, setT := app.UseState[*textinput.Model](c, nil)
+	edited, _ := app.UseState(c, new(bool))
 
 	id := app.UseID(c)
+	clipboard := app.UseClipboard(c)
 
 	app.UseEffect(c, func() {
 		newT := textinput.New()
@@ -88,74 +216,135 @@ func TextField(c *app.Ctx, rawProps app.Props) string {
 		if t == nil {
 			return
 		}
-		if focused {
+		if focused && !props.Disabled {
 			t.Focus()
 			c.UpdateInMs(100)
 		} else {
 			t.Blur()
 		}
-	}, []any{focused})
+	}, []any{t != nil, focused, props.Disabled})
+
+	if t != nil {
+		t.Placeholder = props.Placeholder
+		t.EchoMode = textinput.EchoMode(props.EchoMode)
+		if props.EchoCharacter != 0 {
+			t.EchoCharacter = props.EchoCharacter
+		}
+		t.CharLimit = props.CharLimit
+		t.ShowSuggestions = len(props.Suggestions) > 0
+		t.KeyMap.AcceptSuggestion = props.KeyMap.AcceptSuggestion
+		t.KeyMap.NextSuggestion = props.KeyMap.NextSuggestion
+		t.KeyMap.PrevSuggestion = props.KeyMap.PrevSuggestion
+		t.Styles.Focused.Placeholder = t.Styles.Focused.Placeholder.Foreground(c.Theme.Colors.Base400)
+		t.Styles.Blurred.Placeholder = t.Styles.Blurred.Placeholder.Foreground(c.Theme.Colors.Base400)
+		t.Styles.Focused.Suggestion = t.Styles.Focused.Suggestion.Foreground(c.Theme.Colors.Base500)
+		t.Styles.Blurred.Text = bubblestextinput.DefaultDarkStyles().Blurred.Text
+		if props.Disabled {
+			t.Styles.Blurred.Text = t.Styles.Blurred.Text.Foreground(c.Theme.Colors.Base500)
+		}
+	}
 
 	app.UseEffect(c, func() {
 		if t == nil {
 			return
 		}
-		t.SetValue(props.Value)
-	}, []any{props.Value})
+		t.SetSuggestions(props.Suggestions)
+	}, []any{t != nil, props.Suggestions})
 
 	app.UseEffect(c, func() {
 		if t == nil {
 			return
 		}
-		t.SetWidth(width)
-	}, []any{width})
+		t.SetValue(props.Value)
+	}, []any{t != nil, props.Value})
 
-	app.UseKeyHandler(c, func(keyMsg tea.KeyMsg) bool {
+	app.UseEffect(c, func() {
 		if t == nil {
-			return false
-		}
-
-		if props.OnEnter != nil && keyMsg.String() == "enter" {
-			props.OnEnter()
-			return true
+			return
 		}
+		t.SetWidth(width)
+	}, []any{t != nil, width})
 
-		if keyMsg.String() == "tab" || keyMsg.String() == "shift+tab" || keyMsg.String() == "enter" || keyMsg.String() == "ctrl+c" {
-			return false
-		}
+	if !props.Disabled {
+		app.UseKeyHandler(c, func(keyMsg tea.KeyMsg) bool {
+			if t == nil {
+				return false
+			}
 
-		newT, cmd := t.Update(keyMsg)
-		setT(&newT)
-		c.ExecuteCmd(cmd)
-		if newT.Value() != props.Value && props.OnChange != nil {
-			props.OnChange(newT.Value())
-		}
-		return true
-	})
+			if props.OnEnter != nil && keyMsg.String() == "enter" {
+				props.OnEnter()
+				return true
+			}
 
-	app.UseMsgHandler(c, func(msg tea.Msg) tea.Cmd {
-		if t == nil {
-			return nil
-		}
+			accept := key.Matches(keyMsg, props.KeyMap.AcceptSuggestion) &&
+				len(t.CurrentSuggestion()) > len([]rune(t.Value())) && !props.ReadOnly
+			if !accept && (keyMsg.String() == "tab" || keyMsg.String() == "shift+tab" || keyMsg.String() == "enter" || keyMsg.String() == "ctrl+c") {
+				return false
+			}
+			switch {
+			case key.Matches(keyMsg, props.KeyMap.Copy):
+				if props.EchoMode == EchoNormal {
+					clipboard.Copy(t.Value())
+				}
+				return true
+			case key.Matches(keyMsg, props.KeyMap.Paste):
+				if !props.ReadOnly {
+					clipboard.Paste()
+				}
+				return true
+			}
+			if props.ReadOnly && !isNavigation(t.KeyMap, keyMsg) {
+				return false
+			}
 
-		newT, cmd := t.Update(msg)
-		setT(&newT)
-		return cmd
-	})
-
-	app.UseMouseHandler(c, func(msg tea.MouseMsg, childID string) bool {
-		switch msg.(type) {
-		case tea.MouseReleaseMsg:
-			c.FocusThis(id)
-			if msg.Mouse().Y-y >= lipgloss.Height(props.Title) {
-				t.SetCursor(msg.Mouse().X - x - lipgloss.Width(t.Prompt))
+			newT, cmd := t.Update(keyMsg)
+			setT(&newT)
+			c.ExecuteCmd(cmd)
+			if newT.Value() != props.Value && props.OnChange != nil {
+				*edited = true
+				props.OnChange(newT.Value())
 			}
 			return true
-		}
-		return false
-	})
+		})
 
-	if t != nil {
+		app.UseMsgHandler(c, func(msg tea.Msg) tea.Cmd {
+			if t == nil || props.ReadOnly {
+				return nil
+			}
+			// Text from the clipboard is inserted like a paste.
+			if text, ok := msg.(tea.ClipboardMsg); ok {
+				msg = tea.PasteMsg(text)
+			}
+			if text, ok := msg.(tea.PasteMsg); ok && props.OnPaste != nil {
+				if text = tea.PasteMsg(props.OnPaste(string(text))); text == "" {
+					return nil
+				}
+				msg = text
+			}
+
+			newT, cmd := t.Update(msg)
+			setT(&newT)
+			if newT.Value() != props.Value && props.OnChange != nil {
+				*edited = true
+				props.OnChange(newT.Value())
+			}
+			return cmd
+		})
+
+		app.UseMouseHandler(c, func(msg tea.MouseMsg, childID string) bool {
+			switch msg.(type) {
+			case tea.MouseReleaseMsg:
+				c.FocusThis(id)
+				if msg.Mouse().Y-y >= lipgloss.Height(props.Title) {
+					t.SetCursor(msg.Mouse().X - x - lipgloss.Width(t.Prompt))
+				}
+				return true
+			}
+			return false
+		})
+	}
+
+	if t != nil && !props.Disabled {
 		offsetY := 0
 		if props.Title != "" {
 			offsetY = lipgloss.Height(props.Title)
@@ -197,13 +386,25 @@ func TextField(c *app.Ctx, rawProps app.Props) string {
 	} else {
 		content += "\n" + t.View()
 	}
+	if props.Validate != nil && *edited {
+		if err := props.Validate(props.Value); err != nil {
+			content += "\n" + c.Theme.Text[style.Danger][style.Normal].Render(err.Error())
+		}
+	}
 	return c.MouseZone(s.MaxWidth(width).MaxHeight(height).Render(content))
 }
 
+// isNavigation reports whether the key only moves the cursor.
+func isNavigation(km textinput.KeyMap, keyMsg tea.KeyMsg) bool {
+	return key.Matches(keyMsg, km.CharacterForward, km.CharacterBackward, km.WordForward,
+		km.WordBackward, km.LineStart, km.LineEnd)
+}
+
 func New(c *app.Ctx, onChange func(text string), value string, opts ...prop) *app.C {
 	p := Props{
 		OnChange: onChange,
 		Value:    value,
+		KeyMap:   defaultKeyMap(),
 		Layout:   app.Layout{GrowX: true, GrowY: false},
 	}
 
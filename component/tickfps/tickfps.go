@@ -49,17 +49,21 @@ func TickFPS(c *app.Ctx, props app.Props) string {
 	currentTickTimes := tickTimesVal       // Access state value directly
 	currentTickMsgCount := tickMsgCountVal // Access state value directly
 
+	// The time of a tick frame against the budget of a frame at the max FPS.
+	metrics := c.FrameMetrics()
+	frame := fmt.Sprintf("Frame: %s / %s (%d over)", metrics.Average.Round(10*time.Microsecond), metrics.Budget.Round(10*time.Microsecond), metrics.OverBudget)
+
 	if len(currentTickTimes) < 2 {
-		return fmt.Sprintf("Tick FPS: 0.00 (%d)", currentTickMsgCount)
+		return fmt.Sprintf("Tick FPS: 0.00 (%d) %s", currentTickMsgCount, frame)
 	}
 
 	delta := currentTickTimes[len(currentTickTimes)-1].Sub(currentTickTimes[0]).Seconds()
 	if delta <= 0 {
-		return fmt.Sprintf("Tick FPS: 0.00 (%d)", currentTickMsgCount)
+		return fmt.Sprintf("Tick FPS: 0.00 (%d) %s", currentTickMsgCount, frame)
 	}
 
 	fps := float64(len(currentTickTimes)-1) / delta
-	return fmt.Sprintf("Tick FPS: %.2f (%d)", fps, currentTickMsgCount)
+	return fmt.Sprintf("Tick FPS: %.2f (%d) %s", fps, currentTickMsgCount, frame)
 }
 
 // New creates an instance of the TickFPS that registers its own tick listener
@@ -0,0 +1,105 @@
+package toggle
+
+import (
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/style"
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+type Props struct {
+	Label    string
+	On       bool
+	Disabled bool
+	Variant  style.Variant
+	OnChange func(on bool)
+	app.Layout
+}
+
+type prop func(*Props)
+
+// Toggle is a switch that is turned on and off with space, enter, the arrows
+// or a click. The label is shown next to it.
+func Toggle(c *app.Ctx, rawProps app.Props) string {
+	props, ok := rawProps.(Props)
+	if !ok {
+		panic("Toggle: props must be of type toggle.Props")
+	}
+
+	id := app.UseID(c)
+	// A disabled control can not be focused.
+	focused := !props.Disabled && app.UseIsFocused(c)
+	hovered, _ := app.UseIsHovered(c)
+
+	set := func(on bool) {
+		c.FocusThis(id)
+		if on != props.On && props.OnChange != nil {
+			props.OnChange(on)
+		}
+	}
+
+	if !props.Disabled {
+		app.UseAction(c, func(_ string) {
+			set(!props.On)
+		})
+		app.UseKeyHandler(c, func(keyMsg tea.KeyMsg) bool {
+			switch keyMsg.String() {
+			case "space":
+				set(!props.On)
+			case "right":
+				set(true)
+			case "left":
+				set(false)
+			default:
+				return false
+			}
+			return true
+		})
+	}
+
+	state := style.Normal
+	if props.Disabled {
+		state = style.Disabled
+	} else if hovered {
+		state = style.Hover
+	} else if focused {
+		state = style.Focus
+	}
+
+	track := c.Theme.Toggle[style.Base][state].Render("●  ")
+	if props.On {
+		track = c.Theme.Toggle[props.Variant][state].Render("  ●")
+	}
+	content := track
+	if props.Label != "" {
+		content += " " + c.Theme.Text[style.Base][state].Render(props.Label)
+	}
+	return c.MouseZone(content)
+}
+
+// New creates a toggle switch. onChange is called with the new state.
+func New(c *app.Ctx, label string, on bool, onChange func(on bool), opts ...prop) *app.C {
+	p := Props{
+		Label:    label,
+		On:       on,
+		OnChange: onChange,
+		Variant:  style.Primary,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&p)
+		}
+	}
+	return c.Render(Toggle, p)
+}
+
+func WithVariant(variant style.Variant) prop {
+	return func(p *Props) {
+		p.Variant = variant
+	}
+}
+
+func WithDisabled(disabled bool) prop {
+	return func(p *Props) {
+		p.Disabled = disabled
+	}
+}
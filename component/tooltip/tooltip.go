@@ -0,0 +1,166 @@
+package tooltip
+
+import (
+	"strings"
+	"time"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+type Props struct {
+	Child app.FC
+	Text  string
+	// Content is rendered in the tooltip instead of Text when it is set. It
+	// is laid out with the width of ContentWidth.
+	Content      app.FC
+	ContentWidth int
+	Placement    app.Placement
+	// Delay is how long the mouse has to stay over the child before the
+	// tooltip is shown. A focused child shows it right away.
+	Delay time.Duration
+	Style lipgloss.Style
+	app.Layout
+}
+
+type prop func(*Props)
+
+type tooltipState struct {
+	// since is when the child was hovered or focused.
+	since     time.Time
+	scheduled bool
+	// visible is decided in the final render so the content is rendered in
+	// all phases of the next render.
+	visible bool
+}
+
+// Tooltip shows a tooltip next to its child while the mouse is over it or
+// it has focus.
+func Tooltip(c *app.Ctx, rawProps app.Props) string {
+	props, ok := rawProps.(Props)
+	if !ok {
+		panic("Tooltip: props must be of type tooltip.Props")
+	}
+
+	id := app.UseID(c)
+	state, _ := app.UseState(c, &tooltipState{})
+
+	// The tooltip is for the whole child so it counts when one of the
+	// components inside it is hovered or focused.
+	inside := func(other string) bool {
+		return other == id || strings.HasPrefix(other, id+"_")
+	}
+	hovered := inside(c.UIState.Hovered)
+	focused := inside(c.UIState.Focused)
+
+	content := c.MouseZone(props.Child(c).String())
+
+	tip := ""
+	if state.visible {
+		if props.Content != nil {
+			tip = c.Render(tooltipContent, contentProps{
+				Content: props.Content,
+				Layout:  app.Layout{Width: props.ContentWidth},
+			}).String()
+		} else {
+			tip = props.Text
+		}
+	}
+
+	if c.LayoutPhase == app.LayoutPhaseFinalRender {
+		visible := false
+		switch {
+		case !hovered && !focused:
+			state.since, state.scheduled = time.Time{}, false
+		case focused:
+			visible = true
+		default:
+			if state.since.IsZero() {
+				state.since = time.Now()
+			}
+			wait := props.Delay - time.Since(state.since)
+			if wait <= 0 {
+				visible = true
+			} else if !state.scheduled {
+				state.scheduled = true
+				c.UpdateInMs(int(wait.Milliseconds()) + 1)
+			}
+		}
+		if visible != state.visible {
+			state.visible = visible
+			c.Update()
+		}
+	}
+
+	if state.visible && tip != "" {
+		app.UseOverlay(c, app.Overlay{
+			Content:   props.Style.Render(tip),
+			Anchor:    app.UseBounds(c),
+			Placement: props.Placement,
+		})
+	}
+
+	return content
+}
+
+type contentProps struct {
+	Content app.FC
+	app.Layout
+}
+
+// tooltipContent gives the content its own width so it is not laid out with
+// the width of the child.
+func tooltipContent(c *app.Ctx, rawProps app.Props) string {
+	props, ok := rawProps.(contentProps)
+	if !ok {
+		panic("tooltipContent: props must be of type tooltip.contentProps")
+	}
+	return props.Content(c).String()
+}
+
+// New wraps the child with a tooltip showing the text.
+func New(c *app.Ctx, child app.FC, text string, opts ...prop) *app.C {
+	p := Props{
+		Child:        child,
+		Text:         text,
+		Delay:        500 * time.Millisecond,
+		ContentWidth: 30,
+		Style:        c.Theme.Tooltip,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&p)
+		}
+	}
+	return c.Render(Tooltip, p)
+}
+
+// WithContent renders the component in the tooltip instead of the text.
+func WithContent(content app.FC) prop {
+	return func(p *Props) {
+		p.Content = content
+	}
+}
+
+// WithPlacement sets the side of the child the tooltip is shown on. It is
+// shown on the other side when there is no room.
+func WithPlacement(placement app.Placement) prop {
+	return func(p *Props) {
+		p.Placement = placement
+	}
+}
+func WithContentWidth(width int) prop {
+	return func(p *Props) {
+		p.ContentWidth = width
+	}
+}
+func WithDelay(delay time.Duration) prop {
+	return func(p *Props) {
+		p.Delay = delay
+	}
+}
+func WithStyle(style lipgloss.Style) prop {
+	return func(p *Props) {
+		p.Style = style
+	}
+}
@@ -0,0 +1,273 @@
+package transition
+
+import (
+	"strings"
+	"time"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/style"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Kind is how the content comes in and goes out.
+type Kind int
+
+const (
+	// Fade fades the content in from the background by blending the colors
+	// of the theme. Content replaced by a new trigger is not faded out.
+	Fade Kind = iota
+	// SlideLeft moves the new content in from the right.
+	SlideLeft
+	// SlideRight moves the new content in from the left.
+	SlideRight
+	// SlideUp moves the new content in from the bottom.
+	SlideUp
+	// SlideDown moves the new content in from the top.
+	SlideDown
+	// Wipe uncovers the new content from left to right.
+	Wipe
+)
+
+// Effect is how a transition looks and how long it takes.
+type Effect struct {
+	Kind     Kind
+	Duration time.Duration
+	Easing   app.Easing
+}
+
+type Props struct {
+	Child app.FC
+	// Trigger starts the transition again when it changes and the last
+	// content goes out. It must be comparable.
+	Trigger any
+	// Show is false to transition the child out. It is not rendered once
+	// the transition is done.
+	Show bool
+	// Appear is false to show the child right away when it is mounted.
+	Appear bool
+	Effect
+	app.Layout
+}
+
+type prop func(*Props)
+
+type transitionKey struct {
+	trigger any
+	show    bool
+}
+
+type transitionState struct {
+	key transitionKey
+	// from is the content going out when the trigger changed.
+	from string
+	// last is the content of the last final render.
+	last    string
+	hiding  bool
+	changed bool
+}
+
+// Transition animates its child in when it is mounted, when the trigger changes
+// and when it is shown again, and out when it is hidden.
+func Transition(c *app.Ctx, rawProps app.Props) string {
+	props, ok := rawProps.(Props)
+	if !ok {
+		panic("Transition: props must be of type transition.Props")
+	}
+
+	width, height := app.UseSize(c)
+	key := transitionKey{trigger: props.Trigger, show: props.Show}
+	progress := app.UseTransition(c, key, props.Duration)
+	state, _ := app.UseState(c, &transitionState{key: key, hiding: !props.Show})
+
+	if state.key != key {
+		state.from = state.last
+		if state.key.show != key.show {
+			state.from = ""
+		}
+		state.hiding = !key.show
+		state.key = key
+		state.changed = true
+	}
+
+	done := progress >= 1 || (!props.Appear && !state.changed)
+	if !props.Show && (done || !state.hiding) {
+		state.last = ""
+		return ""
+	}
+
+	amount := props.Easing(progress)
+	var content string
+	if props.Kind == Fade && !done {
+		// The child is rendered with the colors of the theme blended towards
+		// the background.
+		fade := 1 - amount
+		if state.hiding {
+			fade = amount
+		}
+		theme := c.Theme
+		c.Theme = style.NewAppTheme(theme.Colors.Fade(theme.BackgroundColor, fade))
+		content = props.Child(c).String()
+		c.Theme = theme
+	} else {
+		content = props.Child(c).String()
+	}
+	if c.LayoutPhase == app.LayoutPhaseFinalRender {
+		state.last = content
+	}
+
+	if done || props.Kind == Fade || width <= 0 || height <= 0 {
+		return content
+	}
+
+	from, to := state.from, content
+	if state.hiding {
+		from, to = content, ""
+	}
+	// Without growing the transition is as big as the content in it.
+	if !props.GrowX {
+		width = max(lipgloss.Width(from), lipgloss.Width(to))
+	}
+	if !props.GrowY {
+		height = max(lipgloss.Height(from), lipgloss.Height(to))
+	}
+	return render(props.Kind, from, to, amount, width, height)
+}
+
+// render draws the content going out and the content coming in at the
+// amount of the transition.
+func render(kind Kind, from, to string, amount float64, width, height int) string {
+	fromLines, toLines := fit(from, width, height), fit(to, width, height)
+	lines := make([]string, height)
+	x := int(amount*float64(width) + 0.5)
+	y := int(amount*float64(height) + 0.5)
+	for i := range lines {
+		switch kind {
+		case SlideLeft:
+			lines[i] = join(ansi.Cut(fromLines[i], x, width), ansi.Cut(toLines[i], 0, x))
+		case SlideRight:
+			lines[i] = join(ansi.Cut(toLines[i], width-x, width), ansi.Cut(fromLines[i], 0, width-x))
+		case SlideUp:
+			if i < height-y {
+				lines[i] = fromLines[i+y]
+			} else {
+				lines[i] = toLines[i-(height-y)]
+			}
+		case SlideDown:
+			if i < y {
+				lines[i] = toLines[i+height-y]
+			} else {
+				lines[i] = fromLines[i-y]
+			}
+		case Wipe:
+			lines[i] = join(ansi.Cut(toLines[i], 0, x), ansi.Cut(fromLines[i], x, width))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// fit makes the content exactly the width and height so the lines can be
+// cut at the same columns.
+func fit(content string, width, height int) []string {
+	lines := make([]string, height)
+	var contentLines []string
+	if content != "" {
+		contentLines = strings.Split(content, "\n")
+	}
+	for i := range lines {
+		line := ""
+		if i < len(contentLines) {
+			line = ansi.Truncate(contentLines[i], width, "")
+		}
+		lines[i] = line + strings.Repeat(" ", width-ansi.StringWidth(line))
+	}
+	return lines
+}
+
+// join puts two cut lines together without the style of the first one
+// running into the second.
+func join(left, right string) string {
+	return left + ansi.ResetStyle + right
+}
+
+// New wraps the child in a transition. It fades in over 300ms by default.
+func New(c *app.Ctx, child app.FC, opts ...prop) *app.C {
+	p := Props{
+		Child:  child,
+		Show:   true,
+		Appear: true,
+		Effect: Effect{
+			Kind:     Fade,
+			Duration: 300 * time.Millisecond,
+			Easing:   app.EaseOut,
+		},
+		Layout: app.Layout{
+			GrowX: true,
+			GrowY: true,
+		},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&p)
+		}
+	}
+	if p.Easing == nil {
+		p.Easing = app.EaseLinear
+	}
+	return c.Render(Transition, p)
+}
+
+func WithKind(kind Kind) prop {
+	return func(p *Props) {
+		p.Kind = kind
+	}
+}
+func WithDuration(duration time.Duration) prop {
+	return func(p *Props) {
+		p.Duration = duration
+	}
+}
+func WithEasing(easing app.Easing) prop {
+	return func(p *Props) {
+		p.Easing = easing
+	}
+}
+
+// WithEffect sets the kind, duration and easing at once.
+func WithEffect(effect Effect) prop {
+	return func(p *Props) {
+		p.Effect = effect
+	}
+}
+
+// WithTrigger starts the transition again when the trigger changes.
+func WithTrigger(trigger any) prop {
+	return func(p *Props) {
+		p.Trigger = trigger
+	}
+}
+
+// WithShow transitions the child out when it is false and in again when it
+// is true.
+func WithShow(show bool) prop {
+	return func(p *Props) {
+		p.Show = show
+	}
+}
+
+// WithAppear sets whether the child transitions in when it is mounted.
+func WithAppear(appear bool) prop {
+	return func(p *Props) {
+		p.Appear = appear
+	}
+}
+func WithGrowX(grow bool) prop {
+	return func(p *Props) {
+		p.GrowX = grow
+	}
+}
+func WithGrowY(grow bool) prop {
+	return func(p *Props) {
+		p.GrowY = grow
+	}
+}
@@ -0,0 +1,650 @@
+package tree
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/style"
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// Node is a single node in the tree.
+type Node struct {
+	// Key identifies the node among its siblings. The label is used if empty.
+	Key      string
+	Label    string
+	Children []Node
+	// Lazy marks a node whose children are loaded with LoadChildren the
+	// first time it is expanded.
+	Lazy bool
+	// Expanded is the initial state of the node.
+	Expanded bool
+	// Data is not used by the tree. It is passed back in the callbacks.
+	Data any
+}
+
+func (n Node) key() string {
+	if n.Key != "" {
+		return n.Key
+	}
+	return n.Label
+}
+
+// Item is a node as it is shown in the tree.
+type Item struct {
+	// Path is the keys of the node and its parents joined by "/".
+	Path     string
+	Node     Node
+	Depth    int
+	Expanded bool
+}
+
+// Props holds the configuration for the Tree component.
+type Props struct {
+	Key   string
+	Nodes []Node
+	// LoadChildren is called once for lazy nodes when they are first shown
+	// expanded. It runs in a goroutine and a loading row is shown under the
+	// node until it returns.
+	LoadChildren func(item Item) []Node
+	// Guides draws lines connecting the nodes to their parents.
+	Guides     bool
+	WheelDelta int
+	// OnSelect is called when the cursor moves to another node.
+	OnSelect func(item Item)
+	// OnActivate is called when enter is pressed or a leaf is double clicked.
+	OnActivate func(item Item)
+	OnToggle   func(item Item, expanded bool)
+	KeyMap     KeyMap
+	Styles     style.TreeTheme
+	app.Layout
+}
+
+type prop func(*Props)
+
+type KeyMap struct {
+	LineUp     key.Binding
+	LineDown   key.Binding
+	PageUp     key.Binding
+	PageDown   key.Binding
+	GotoTop    key.Binding
+	GotoBottom key.Binding
+	Expand     key.Binding
+	Collapse   key.Binding
+	Activate   key.Binding
+}
+
+func (km KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{km.LineUp, km.LineDown, km.Expand, km.Collapse}
+}
+
+func (km KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{km.LineUp, km.LineDown, km.PageUp, km.PageDown, km.GotoTop, km.GotoBottom},
+		{km.Expand, km.Collapse, km.Activate},
+	}
+}
+
+// The default key map has no letters so they can be used for type-ahead.
+func defaultKeyMap() KeyMap {
+	return KeyMap{
+		LineUp: key.NewBinding(
+			key.WithKeys("up"),
+			key.WithHelp("↑", "up"),
+		),
+		LineDown: key.NewBinding(
+			key.WithKeys("down"),
+			key.WithHelp("↓", "down"),
+		),
+		PageUp: key.NewBinding(
+			key.WithKeys("pgup"),
+			key.WithHelp("pgup", "page up"),
+		),
+		PageDown: key.NewBinding(
+			key.WithKeys("pgdown"),
+			key.WithHelp("pgdn", "page down"),
+		),
+		GotoTop: key.NewBinding(
+			key.WithKeys("home"),
+			key.WithHelp("home", "go to start"),
+		),
+		GotoBottom: key.NewBinding(
+			key.WithKeys("end"),
+			key.WithHelp("end", "go to end"),
+		),
+		Expand: key.NewBinding(
+			key.WithKeys("right"),
+			key.WithHelp("→", "expand"),
+		),
+		Collapse: key.NewBinding(
+			key.WithKeys("left"),
+			key.WithHelp("←", "collapse"),
+		),
+		Activate: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "toggle/activate"),
+		),
+	}
+}
+
+// loadingLabel is shown under a lazy node while its children load.
+const loadingLabel = "Loading…"
+
+// typeAheadTimeout is how long typed characters are combined into one search.
+const typeAheadTimeout = time.Second
+
+// doubleClickInterval is the longest time between two clicks on the same node
+// for them to count as a double click.
+const doubleClickInterval = 400 * time.Millisecond
+
+// treeState is mutated in place by the event handlers which call c.Update().
+type treeState struct {
+	cursor      string // Path of the node with the cursor
+	cursorIndex int    // Used when the cursor node is no longer visible
+	top         int
+	follow      bool
+	expanded    map[string]bool
+	loaded      map[string][]Node
+	loading     map[string]bool
+
+	typeAhead   string
+	typeAheadAt time.Time
+	lastClick   time.Time
+	lastPath    string
+}
+
+// row is a visible node together with the guides in front of it.
+type row struct {
+	item   Item
+	branch bool
+	prefix string
+	// loading marks the row shown while the children of its parent load.
+	loading bool
+}
+
+// Tree is the functional component for rendering a tree of nodes. Only the
+// rows that fit in the height given by the layout are rendered.
+func Tree(c *app.Ctx, rawProps app.Props) string {
+	props, ok := rawProps.(Props)
+	if !ok {
+		panic("Tree: props must be of type tree.Props")
+	}
+
+	id := app.UseID(c)
+	isFocused := app.UseIsFocused(c)
+	_, childHoverID := app.UseIsHovered(c)
+	state, _ := app.UseState(c, &treeState{
+		follow:   true,
+		expanded: make(map[string]bool),
+		loaded:   make(map[string][]Node),
+		loading:  make(map[string]bool),
+	})
+
+	width, height := app.UseSize(c)
+
+	var load func(item Item)
+	if props.LoadChildren != nil {
+		load = func(item Item) {
+			state.loading[item.Path] = true
+			c.Go(func() func() {
+				children := props.LoadChildren(item)
+				return func() {
+					state.loaded[item.Path] = children
+					delete(state.loading, item.Path)
+				}
+			})
+		}
+	}
+
+	rows := flatten(props.Nodes, state, load, "", 0, "", props.Guides)
+	count := len(rows)
+
+	cursor := indexOf(rows, state.cursor)
+	if cursor < 0 && count > 0 {
+		cursor = clamp(state.cursorIndex, 0, count-1)
+	}
+
+	setCursor := func(index int) {
+		if count == 0 {
+			return
+		}
+		index = clamp(index, 0, count-1)
+		state.follow = true
+		state.cursorIndex = index
+		if rows[index].item.Path == state.cursor {
+			return
+		}
+		state.cursor = rows[index].item.Path
+		c.Update()
+		if props.OnSelect != nil && !rows[index].loading {
+			props.OnSelect(rows[index].item)
+		}
+	}
+
+	setExpanded := func(index int, expanded bool) {
+		r := rows[index]
+		if !r.branch || r.item.Expanded == expanded {
+			return
+		}
+		state.expanded[r.item.Path] = expanded
+		// Keep the cursor on the collapsed node if it was inside it.
+		if !expanded && strings.HasPrefix(state.cursor, r.item.Path+"/") {
+			state.cursor = r.item.Path
+			state.cursorIndex = index
+		}
+		c.Update()
+		if props.OnToggle != nil {
+			r.item.Expanded = expanded
+			props.OnToggle(r.item, expanded)
+		}
+	}
+
+	activate := func(index int) {
+		if rows[index].loading {
+			return
+		}
+		if rows[index].branch {
+			setExpanded(index, !rows[index].item.Expanded)
+			return
+		}
+		if props.OnActivate != nil {
+			props.OnActivate(rows[index].item)
+		}
+	}
+
+	app.UseKeyHandler(c, func(keyMsg tea.KeyMsg) bool {
+		if count == 0 {
+			return false
+		}
+		page := max(height, 1)
+		switch {
+		case key.Matches(keyMsg, props.KeyMap.LineUp):
+			setCursor(cursor - 1)
+		case key.Matches(keyMsg, props.KeyMap.LineDown):
+			setCursor(cursor + 1)
+		case key.Matches(keyMsg, props.KeyMap.PageUp):
+			setCursor(cursor - page)
+		case key.Matches(keyMsg, props.KeyMap.PageDown):
+			setCursor(cursor + page)
+		case key.Matches(keyMsg, props.KeyMap.GotoTop):
+			setCursor(0)
+		case key.Matches(keyMsg, props.KeyMap.GotoBottom):
+			setCursor(count - 1)
+		case key.Matches(keyMsg, props.KeyMap.Expand):
+			r := rows[cursor]
+			if !r.branch {
+				return false
+			}
+			if !r.item.Expanded {
+				setExpanded(cursor, true)
+			} else if cursor+1 < count && rows[cursor+1].item.Depth > r.item.Depth {
+				setCursor(cursor + 1)
+			}
+		case key.Matches(keyMsg, props.KeyMap.Collapse):
+			r := rows[cursor]
+			if r.branch && r.item.Expanded {
+				setExpanded(cursor, false)
+			} else if parent := parentIndex(rows, cursor); parent >= 0 {
+				setCursor(parent)
+			} else {
+				return false
+			}
+		case key.Matches(keyMsg, props.KeyMap.Activate):
+			activate(cursor)
+		default:
+			text := keyMsg.Key().Text
+			if text == "" || text == " " && state.typeAhead == "" {
+				return false
+			}
+			return typeAhead(state, rows, cursor, text, setCursor)
+		}
+		return true
+	})
+
+	app.UseMouseHandler(c, func(msg tea.MouseMsg, childID string) bool {
+		switch msg := msg.(type) {
+		case tea.MouseWheelMsg:
+			delta := max(props.WheelDelta, 1)
+			switch msg.Button {
+			case tea.MouseWheelDown:
+				if state.top+height >= count {
+					return false
+				}
+				state.top = min(state.top+delta, count-1)
+			case tea.MouseWheelUp:
+				if state.top <= 0 {
+					return false
+				}
+				state.top = max(state.top-delta, 0)
+			default:
+				return false
+			}
+			state.follow = false
+			c.Update()
+			return true
+		case tea.MouseReleaseMsg:
+			if msg.Button != tea.MouseLeft {
+				return false
+			}
+			kind, rawIndex, found := strings.Cut(childID, ":")
+			index, err := strconv.Atoi(rawIndex)
+			if !found || err != nil || index < 0 || index >= count {
+				return false
+			}
+			c.FocusThis(id)
+			setCursor(index)
+			path := rows[index].item.Path
+			switch {
+			case kind == "toggle":
+				setExpanded(index, !rows[index].item.Expanded)
+			case state.lastPath == path && time.Since(state.lastClick) < doubleClickInterval:
+				state.lastPath = ""
+				activate(index)
+			default:
+				state.lastPath = path
+				state.lastClick = time.Now()
+			}
+			return true
+		}
+		return false
+	})
+
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+
+	// Keep the cursor inside the window. The sizes are only final in the
+	// last layout phase so the state is not touched before that.
+	top := 0
+	if count > 0 {
+		top = clamp(state.top, 0, count-1)
+		if state.follow {
+			if cursor < top {
+				top = cursor
+			} else if cursor >= top+height {
+				top = cursor - height + 1
+			}
+		}
+		top = clamp(top, 0, max(count-height, 0))
+	}
+	if c.LayoutPhase == app.LayoutPhaseFinalRender {
+		state.top, state.follow = top, false
+		if count > 0 {
+			state.cursor, state.cursorIndex = rows[cursor].item.Path, cursor
+		}
+	}
+
+	lines := make([]string, 0, height)
+	for i := top; i < count && len(lines) < height; i++ {
+		lines = append(lines, renderRow(c, props, rows[i], i, width, i == cursor, isFocused, childHoverID))
+	}
+
+	s := lipgloss.NewStyle()
+	if c.CurrentBg != nil {
+		s = s.Background(c.CurrentBg)
+	}
+	return c.MouseZone(s.Width(width).Height(height).Render(strings.Join(lines, "\n")))
+}
+
+// renderRow renders the guides and the indicator as one zone that toggles the
+// node and the label as another zone that moves the cursor.
+func renderRow(c *app.Ctx, props Props, r row, index int, width int, isCursor bool, isFocused bool, childHoverID string) string {
+	toggleID := "toggle:" + strconv.Itoa(index)
+	nodeID := "node:" + strconv.Itoa(index)
+
+	s := props.Styles.Node
+	if r.loading {
+		s = props.Styles.Indicator.Inherit(s)
+	}
+	if childHoverID == toggleID || childHoverID == nodeID {
+		s = s.Inherit(props.Styles.Hovered)
+	} else if isCursor && isFocused {
+		s = s.Inherit(props.Styles.CursorFocus)
+	} else if isCursor {
+		s = s.Inherit(props.Styles.Cursor)
+	}
+	if c.CurrentBg != nil {
+		s = s.Inherit(lipgloss.NewStyle().Background(c.CurrentBg))
+	}
+	bg := lipgloss.NewStyle().Background(s.GetBackground())
+
+	indicator := "  "
+	if r.branch && r.item.Expanded {
+		indicator = "▾ "
+	} else if r.branch {
+		indicator = "▸ "
+	}
+	prefix := props.Styles.Guide.Inherit(bg).Render(r.prefix) + props.Styles.Indicator.Inherit(bg).Render(indicator)
+	prefixWidth := lipgloss.Width(prefix)
+	if prefixWidth >= width {
+		return c.MouseZoneChild(toggleID, lipgloss.NewStyle().MaxWidth(width).Render(prefix))
+	}
+
+	labelWidth := width - prefixWidth
+	label := s.Width(labelWidth).MaxWidth(labelWidth).Render(runewidth.Truncate(r.item.Node.Label, labelWidth, "…"))
+	return c.MouseZoneChild(toggleID, prefix) + c.MouseZoneChild(nodeID, label)
+}
+
+// flatten returns the visible nodes in the order they are shown. Loading the
+// children of expanded lazy nodes is started the first time they are needed
+// and a loading row is shown in their place until they are loaded.
+func flatten(nodes []Node, state *treeState, load func(item Item), parentPath string, depth int, prefix string, guides bool) []row {
+	var rows []row
+	for i, node := range nodes {
+		path := node.key()
+		if parentPath != "" {
+			path = parentPath + "/" + path
+		}
+		expanded, ok := state.expanded[path]
+		if !ok {
+			expanded = node.Expanded
+		}
+		branch := node.Lazy || len(node.Children) > 0
+		item := Item{
+			Path:     path,
+			Node:     node,
+			Depth:    depth,
+			Expanded: branch && expanded,
+		}
+
+		children := node.Children
+		loading := false
+		if node.Lazy {
+			loaded, ok := state.loaded[path]
+			if !ok && expanded && load != nil {
+				if !state.loading[path] {
+					load(item)
+				}
+				loading = true
+			}
+			children = loaded
+		}
+		last := i == len(nodes)-1
+
+		nodePrefix, childPrefix := prefix, prefix
+		if guides {
+			if last {
+				nodePrefix += "└─"
+				childPrefix += "  "
+			} else {
+				nodePrefix += "├─"
+				childPrefix += "│ "
+			}
+		} else {
+			childPrefix += "  "
+		}
+
+		rows = append(rows, row{
+			item:   item,
+			branch: branch,
+			prefix: nodePrefix,
+		})
+		if loading {
+			loadingPrefix := childPrefix
+			if guides {
+				loadingPrefix += "└─"
+			}
+			rows = append(rows, row{
+				item: Item{
+					Path:  path + "/" + loadingLabel,
+					Node:  Node{Label: loadingLabel},
+					Depth: depth + 1,
+				},
+				prefix:  loadingPrefix,
+				loading: true,
+			})
+		} else if branch && expanded {
+			rows = append(rows, flatten(children, state, load, path, depth+1, childPrefix, guides)...)
+		}
+	}
+	return rows
+}
+
+// typeAhead moves the cursor to the next node starting with the typed text.
+// Characters typed shortly after each other are combined into one search.
+func typeAhead(state *treeState, rows []row, cursor int, text string, setCursor func(int)) bool {
+	start := cursor + 1
+	if time.Since(state.typeAheadAt) < typeAheadTimeout {
+		state.typeAhead += text
+		start = cursor
+	} else {
+		state.typeAhead = text
+	}
+	state.typeAheadAt = time.Now()
+
+	query := strings.ToLower(state.typeAhead)
+	for i := range rows {
+		index := (start + i) % len(rows)
+		if strings.HasPrefix(strings.ToLower(rows[index].item.Node.Label), query) {
+			setCursor(index)
+			return true
+		}
+	}
+	return true
+}
+
+func indexOf(rows []row, path string) int {
+	for i, r := range rows {
+		if r.item.Path == path {
+			return i
+		}
+	}
+	return -1
+}
+
+func parentIndex(rows []row, index int) int {
+	for i := index - 1; i >= 0; i-- {
+		if rows[i].item.Depth < rows[index].item.Depth {
+			return i
+		}
+	}
+	return -1
+}
+
+func clamp(v, low, high int) int {
+	if high < low {
+		return low
+	}
+	return min(max(v, low), high)
+}
+
+// New creates a new tree showing the given root nodes.
+func New(c *app.Ctx, nodes []Node, opts ...prop) *app.C {
+	p := Props{
+		Nodes:      nodes,
+		Guides:     true,
+		WheelDelta: 3,
+		KeyMap:     defaultKeyMap(),
+		Styles:     c.Theme.Tree,
+		Layout: app.Layout{
+			GrowX: true,
+			GrowY: true,
+		},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&p)
+		}
+	}
+	return c.Render(Tree, p)
+}
+
+// --- Prop Option Functions ---
+
+func WithKey(key string) prop {
+	return func(props *Props) {
+		props.Key = key
+	}
+}
+
+// WithLoadChildren sets the function loading the children of lazy nodes.
+func WithLoadChildren(loadChildren func(item Item) []Node) prop {
+	return func(props *Props) {
+		props.LoadChildren = loadChildren
+	}
+}
+
+// WithGuides sets if lines are drawn between the nodes and their parents.
+func WithGuides(guides bool) prop {
+	return func(props *Props) {
+		props.Guides = guides
+	}
+}
+
+// WithWheelDelta sets the number of rows scrolled per mouse wheel event.
+func WithWheelDelta(delta int) prop {
+	return func(props *Props) {
+		props.WheelDelta = delta
+	}
+}
+
+// WithOnSelect is called when the cursor moves to another node.
+func WithOnSelect(onSelect func(item Item)) prop {
+	return func(props *Props) {
+		props.OnSelect = onSelect
+	}
+}
+
+// WithOnActivate is called when enter is pressed on a leaf or it is double
+// clicked. Branches are expanded and collapsed instead.
+func WithOnActivate(onActivate func(item Item)) prop {
+	return func(props *Props) {
+		props.OnActivate = onActivate
+	}
+}
+
+// WithOnToggle is called when a node is expanded or collapsed.
+func WithOnToggle(onToggle func(item Item, expanded bool)) prop {
+	return func(props *Props) {
+		props.OnToggle = onToggle
+	}
+}
+
+func WithKeyMap(keyMap KeyMap) prop {
+	return func(props *Props) {
+		props.KeyMap = keyMap
+	}
+}
+
+func WithStyles(styles style.TreeTheme) prop {
+	return func(props *Props) {
+		props.Styles = styles
+	}
+}
+
+func WithGrowX(grow bool) prop {
+	return func(props *Props) {
+		props.Layout.GrowX = grow
+	}
+}
+
+func WithGrowY(grow bool) prop {
+	return func(props *Props) {
+		props.Layout.GrowY = grow
+	}
+}
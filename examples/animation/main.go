@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/component/button"
+	"github.com/alexanderbh/bubbleapp/component/stack"
+	"github.com/alexanderbh/bubbleapp/component/text"
+	"github.com/alexanderbh/bubbleapp/style"
+	"github.com/charmbracelet/lipgloss/v2"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+var fills = []float64{0.2, 0.9, 0.5, 1, 0}
+
+var items = []string{"Inbox", "Drafts", "Sent", "Archive"}
+
+func NewRoot(c *app.Ctx) *app.C {
+	open, setOpen := app.UseState(c, true)
+	fill, setFill := app.UseState(c, 0)
+	active, setActive := app.UseState(c, 0)
+
+	panelTarget := 4.0
+	if open {
+		panelTarget = 24
+	}
+	panelWidth := app.UseSpring(c, panelTarget, app.DefaultSpring)
+
+	return stack.New(c, func(c *app.Ctx) []*app.C {
+		return []*app.C{
+			stack.New(c, func(c *app.Ctx) []*app.C {
+				return []*app.C{
+					button.New(c, "Toggle panel", func() {
+						setOpen(!open)
+					}),
+					button.New(c, "Fill", func() {
+						setFill((fill + 1) % len(fills))
+					}, button.WithVariant(style.Secondary)),
+					button.New(c, "Next item", func() {
+						setActive((active + 1) % len(items))
+					}, button.WithVariant(style.Tertiary)),
+				}
+			}, stack.WithDirection(app.Horizontal), stack.WithGap(2), stack.WithGrowY(false)),
+
+			c.Render(bar, barProps{Value: fills[fill], Layout: app.Layout{GrowX: true}}),
+
+			text.New(c, fmt.Sprintf("The panel follows its width with a spring: %.1f", panelWidth)),
+
+			stack.New(c, func(c *app.Ctx) []*app.C {
+				children := make([]*app.C, len(items))
+				for i, label := range items {
+					children[i] = c.Render(item, itemProps{
+						Key:    label,
+						Label:  label,
+						Active: i == active,
+						Layout: app.Layout{Width: int(panelWidth + 0.5)},
+					})
+				}
+				return children
+			}, stack.WithGrowY(false)),
+		}
+	})
+}
+
+type barProps struct {
+	Value float64
+	app.Layout
+}
+
+// bar eases to its value when it changes.
+func bar(c *app.Ctx, rawProps app.Props) string {
+	props := rawProps.(barProps)
+	value := app.UseAnimatedValue(c, props.Value, 600*time.Millisecond, app.EaseInOut)
+	width, _ := app.UseSize(c)
+	filled := int(value * float64(width))
+	return c.Theme.Text[style.Success][style.Normal].Render(strings.Repeat("█", filled)) +
+		c.Theme.Text[style.Base][style.Normal].Render(strings.Repeat("░", max(0, width-filled)))
+}
+
+type itemProps struct {
+	Key    string
+	Label  string
+	Active bool
+	app.Layout
+}
+
+// item blends its highlight in and out when it becomes active.
+func item(c *app.Ctx, rawProps app.Props) string {
+	props := rawProps.(itemProps)
+	target := c.Theme.Colors.Base800
+	if props.Active {
+		target = c.Theme.Colors.Primary
+	}
+	bg := app.UseAnimatedColor(c, target, 300*time.Millisecond, app.EaseOut)
+	return lipgloss.NewStyle().Background(bg).Foreground(c.Theme.Colors.Base50).Width(props.Width).MaxWidth(props.Width).Render(" " + props.Label)
+}
+
+func main() {
+	c := app.NewCtx()
+
+	bubbleApp := app.New(c, NewRoot)
+	p := tea.NewProgram(bubbleApp, tea.WithAltScreen(), tea.WithMouseAllMotion())
+	bubbleApp.SetTeaProgram(p)
+	if _, err := p.Run(); err != nil {
+		os.Exit(1)
+	}
+}
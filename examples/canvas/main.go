@@ -0,0 +1,62 @@
+package main
+
+import (
+	"math"
+	"os"
+	"time"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/component/canvas"
+	"github.com/alexanderbh/bubbleapp/component/stack"
+	"github.com/alexanderbh/bubbleapp/component/text"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+func NewRoot(c *app.Ctx) *app.C {
+	frame, setFrame := app.UseState(c, 0)
+
+	app.UseTick(c, 50*time.Millisecond, func() {
+		setFrame(func(prev int) int { return prev + 1 })
+	})
+
+	colors := c.Theme.Colors
+	t := float64(frame) / 10
+
+	return stack.New(c, func(c *app.Ctx) []*app.C {
+		return []*app.C{
+			text.New(c, "Braille"),
+			canvas.New(c, func(s *canvas.Surface) {
+				w, h := s.Size()
+				s.Rect(0, 0, w, h, colors.Base600)
+				for x := range w {
+					y := h/2 + int(float64(h/3)*math.Sin(float64(x)/8+t))
+					s.Set(x, y, colors.Info)
+				}
+				s.Circle(w/4, h/2, h/3, colors.Warning)
+				s.Line(0, h-1, w-1, 0, colors.Danger)
+				s.Text(4, 4, " sin(x) ", colors.Base50)
+			}, canvas.WithHeight(8)),
+			text.New(c, "Half blocks"),
+			canvas.New(c, func(s *canvas.Surface) {
+				w, h := s.Size()
+				s.FillRect(0, 0, w, h, colors.Base800)
+				x := int(float64(w-12)*(math.Sin(t/3)+1)/2) + 6
+				y := int(float64(h-12)*math.Abs(math.Sin(t))) + 6
+				s.FillCircle(x, h-y, 5, colors.Primary)
+				s.FillRect(2, h-3, w-4, 2, colors.Success)
+			}, canvas.WithMode(canvas.HalfBlock), canvas.WithGrowY(true)),
+		}
+	})
+}
+
+func main() {
+	c := app.NewCtx()
+
+	bubbleApp := app.New(c, NewRoot)
+	p := tea.NewProgram(bubbleApp, tea.WithAltScreen(), tea.WithMouseAllMotion())
+	bubbleApp.SetTeaProgram(p)
+	if _, err := p.Run(); err != nil {
+		os.Exit(1)
+	}
+}
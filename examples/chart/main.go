@@ -0,0 +1,73 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/component/chart"
+	"github.com/alexanderbh/bubbleapp/component/stack"
+	"github.com/alexanderbh/bubbleapp/component/text"
+	"github.com/alexanderbh/bubbleapp/style"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+var disks = []chart.Bar{
+	{Label: "root", Value: 71, Variant: style.Primary},
+	{Label: "home", Value: 43, Variant: style.Secondary},
+	{Label: "var", Value: 88, Variant: style.Warning},
+	{Label: "tmp", Value: 12, Variant: style.Success},
+}
+
+func NewRoot(c *app.Ctx) *app.C {
+	cpu := chart.UseStream(c, 200)
+	memory := chart.UseStream(c, 200)
+	latency := chart.UseStream(c, 500)
+	step, setStep := app.UseState(c, 0)
+
+	app.UseTick(c, 200*time.Millisecond, func() {
+		t := float64(step)
+		cpu.Push(50 + 35*math.Sin(t/8) + rand.Float64()*10)
+		memory.Push(40 + 10*math.Sin(t/30) + rand.Float64()*3)
+		for range 5 {
+			latency.Push(math.Abs(rand.NormFloat64()*20 + 80))
+		}
+		setStep(step + 1)
+	})
+
+	return stack.New(c, func(c *app.Ctx) []*app.C {
+		return []*app.C{
+			stack.New(c, func(c *app.Ctx) []*app.C {
+				return []*app.C{
+					text.New(c, "CPU "),
+					chart.NewSparkline(c, cpu.Values(), chart.WithRange(0, 100), chart.WithVariant(style.Success)),
+				}
+			}, stack.WithDirection(app.Horizontal), stack.WithGrowY(false)),
+			chart.NewLine(c, []chart.Series{
+				{Name: "cpu %", Values: cpu.Values(), Variant: style.Success},
+				{Name: "memory %", Values: memory.Values(), Variant: style.Info},
+			}, chart.WithRange(0, 100), chart.WithHeight(12)),
+			stack.New(c, func(c *app.Ctx) []*app.C {
+				return []*app.C{
+					chart.NewBar(c, disks, chart.WithRange(0, 100)),
+					chart.NewHistogram(c, latency.Values(), chart.WithBins(12), chart.WithVariant(style.Tertiary)),
+				}
+			}, stack.WithDirection(app.Horizontal), stack.WithGap(2), stack.WithGrowY(false)),
+			text.New(c, "Hover the charts with the mouse to see the values."),
+		}
+	})
+}
+
+func main() {
+	c := app.NewCtx()
+
+	bubbleApp := app.New(c, NewRoot)
+	p := tea.NewProgram(bubbleApp, tea.WithAltScreen(), tea.WithMouseAllMotion())
+	bubbleApp.SetTeaProgram(p)
+	if _, err := p.Run(); err != nil {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/component/checkbox"
+	"github.com/alexanderbh/bubbleapp/component/divider"
+	"github.com/alexanderbh/bubbleapp/component/radio"
+	"github.com/alexanderbh/bubbleapp/component/stack"
+	"github.com/alexanderbh/bubbleapp/component/text"
+	"github.com/alexanderbh/bubbleapp/component/toggle"
+	"github.com/alexanderbh/bubbleapp/style"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+var sizes = []string{"Small", "Medium", "Large"}
+var colors = []string{"Red", "Green", "Blue"}
+
+func NewRoot(c *app.Ctx) *app.C {
+	notifications, setNotifications := app.UseState(c, true)
+	newsletter, setNewsletter := app.UseState(c, false)
+	darkMode, setDarkMode := app.UseState(c, true)
+	autoSave, setAutoSave := app.UseState(c, false)
+	size, setSize := app.UseState(c, 1)
+	color, setColor := app.UseState(c, -1)
+
+	return stack.New(c, func(c *app.Ctx) []*app.C {
+		return []*app.C{
+			checkbox.New(c, "Notifications", notifications, func(checked bool) { setNotifications(checked) }),
+			checkbox.New(c, "Newsletter", newsletter, func(checked bool) { setNewsletter(checked) }, checkbox.WithVariant(style.Success)),
+			checkbox.New(c, "Terms (disabled)", true, nil, checkbox.WithDisabled(true)),
+			divider.New(c),
+			toggle.New(c, "Dark mode", darkMode, func(on bool) { setDarkMode(on) }),
+			toggle.New(c, "Auto save", autoSave, func(on bool) { setAutoSave(on) }, toggle.WithVariant(style.Warning)),
+			divider.New(c),
+			radio.New(c, sizes, size, func(index int) { setSize(index) }),
+			radio.New(c, colors, color, func(index int) { setColor(index) },
+				radio.WithDirection(app.Horizontal), radio.WithVariant(style.Secondary)),
+			divider.New(c),
+			text.New(c, fmt.Sprintf("Notifications: %t, Newsletter: %t, Dark mode: %t, Auto save: %t, Size: %d, Color: %d",
+				notifications, newsletter, darkMode, autoSave, size, color)),
+			text.New(c, "Use [tab] to move, [space] to check and the arrows to choose.", text.WithFg(c.Theme.Colors.DangerFg)),
+		}
+	})
+}
+
+func main() {
+	c := app.NewCtx()
+
+	bubbleApp := app.New(c, NewRoot)
+	p := tea.NewProgram(bubbleApp, tea.WithAltScreen(), tea.WithMouseAllMotion())
+	bubbleApp.SetTeaProgram(p)
+	if _, err := p.Run(); err != nil {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/component/divider"
+	"github.com/alexanderbh/bubbleapp/component/dropdown"
+	"github.com/alexanderbh/bubbleapp/component/stack"
+	"github.com/alexanderbh/bubbleapp/component/text"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+var languages = []dropdown.Option{
+	{Label: "Go", Value: "go"},
+	{Label: "Rust", Value: "rust"},
+	{Label: "Python", Value: "python"},
+	{Label: "TypeScript", Value: "typescript"},
+	{Label: "COBOL", Value: "cobol", Disabled: true},
+	{Label: "Haskell", Value: "haskell"},
+	{Label: "Zig", Value: "zig"},
+}
+
+var cities = dropdown.Options("Amsterdam", "Athens", "Berlin", "Bern", "Brussels", "Copenhagen",
+	"Dublin", "Helsinki", "Lisbon", "London", "Madrid", "Oslo", "Paris", "Prague", "Rome", "Stockholm", "Vienna")
+
+var tags = dropdown.Options("bug", "feature", "docs", "help wanted", "good first issue")
+
+func NewRoot(c *app.Ctx) *app.C {
+	language, setLanguage := app.UseState(c, -1)
+	city, setCity := app.UseState(c, "")
+	selectedTags, setSelectedTags := app.UseState(c, []int{1})
+
+	labels := make([]string, len(selectedTags))
+	for i, index := range selectedTags {
+		labels[i] = tags[index].Label
+	}
+
+	return stack.New(c, func(c *app.Ctx) []*app.C {
+		return []*app.C{
+			text.New(c, "Language:"),
+			dropdown.New(c, languages, language, func(index int) { setLanguage(index) },
+				dropdown.WithPlaceholder("Pick a language")),
+			text.New(c, "City:"),
+			dropdown.NewCombobox(c, cities, city, func(text string) { setCity(text) },
+				dropdown.WithPlaceholder("Type a city"), dropdown.WithMaxHeight(5)),
+			text.New(c, "Tags:"),
+			dropdown.NewMulti(c, tags, selectedTags, func(selected []int) { setSelectedTags(selected) },
+				dropdown.WithPlaceholder("No tags")),
+			divider.New(c),
+			text.New(c, fmt.Sprintf("Language: %d, City: %q, Tags: %s", language, city, strings.Join(labels, ", "))),
+			text.New(c, "Use [tab] to move between the fields and [enter] or the mouse to open them.", text.WithFg(c.Theme.Colors.DangerFg)),
+		}
+	})
+}
+
+func main() {
+	c := app.NewCtx()
+
+	bubbleApp := app.New(c, NewRoot)
+	p := tea.NewProgram(bubbleApp, tea.WithAltScreen(), tea.WithMouseAllMotion())
+	bubbleApp.SetTeaProgram(p)
+	if _, err := p.Run(); err != nil {
+		os.Exit(1)
+	}
+}
@@ -1,58 +1,84 @@
 package main
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/alexanderbh/bubbleapp/app"
 	"github.com/alexanderbh/bubbleapp/component/box"
 	"github.com/alexanderbh/bubbleapp/component/button"
 	"github.com/alexanderbh/bubbleapp/component/divider"
+	"github.com/alexanderbh/bubbleapp/component/dropdown"
 	"github.com/alexanderbh/bubbleapp/component/form"
 	"github.com/alexanderbh/bubbleapp/component/stack"
 	"github.com/alexanderbh/bubbleapp/component/text"
 	"github.com/alexanderbh/bubbleapp/style"
 
 	tea "github.com/charmbracelet/bubbletea/v2"
-	"github.com/charmbracelet/huh/v2"
 	"github.com/charmbracelet/lipgloss/v2"
 )
 
-type FormData struct {
-	email    string
-	password string
-	remember string
+var emailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+// emailAvailable pretends to ask a server if the email is already in use.
+func emailAvailable(value any) error {
+	time.Sleep(500 * time.Millisecond)
+	if email, _ := value.(string); strings.HasPrefix(email, "taken@") {
+		return errors.New("This email is already registered")
+	}
+	return nil
 }
 
-var loginForm = huh.NewForm(
-	huh.NewGroup(
-		huh.NewInput().Key("email").Title("Email"),
-		huh.NewInput().Key("password").Title("Password").EchoMode(huh.EchoModePassword),
-		huh.NewSelect[string]().Key("rememberme").Title("Remember me").Description("Log in automatically when using this SSH key").Options(huh.NewOptions("Yes", "No")...),
-	),
-).WithTheme(huh.ThemeFunc(huh.ThemeDracula)).WithShowHelp(false)
+var countries = []dropdown.Option{
+	{Label: "Denmark", Value: "dk"},
+	{Label: "Germany", Value: "de"},
+	{Label: "Sweden", Value: "se"},
+}
 
 func NewRoot(c *app.Ctx) *app.C {
-	formSubmit, setFormSubmit := app.UseState[*FormData](c, nil)
+	submitted, setSubmitted := app.UseState[form.Values](c, nil)
+
+	f := form.UseForm(c, form.Values{
+		"email":    "",
+		"password": "",
+		"country":  "dk",
+		"plan":     "Free",
+		"remember": false,
+	}, func(values form.Values) {
+		setSubmitted(values)
+	})
 
 	return stack.New(c, func(c *app.Ctx) []*app.C {
 		cs := []*app.C{}
 		cs = append(cs, c.Render(loginLogo, nil))
 
-		if formSubmit == nil {
-			cs = append(cs, form.New(c, loginForm, func() {
-				setFormSubmit(&FormData{
-					email:    loginForm.GetString("email"),
-					password: loginForm.GetString("password"),
-					remember: loginForm.GetString("rememberme"),
-				})
-			}))
+		if submitted == nil {
+			cs = append(cs, form.New(c, func(c *app.Ctx) []*app.C {
+				return []*app.C{
+					form.TextField(c, f, "email", form.WithLabel("Email"),
+						form.WithValidate(form.Required("Email is required"), form.Match(emailPattern, "Not a valid email")),
+						form.WithAsyncValidate(emailAvailable)),
+					form.Password(c, f, "password", form.WithLabel("Password"),
+						form.WithValidate(form.Required("Password is required"), form.MinLength(8))),
+					form.Select(c, f, "country", countries, form.WithLabel("Country")),
+					form.Radio(c, f, "plan", []string{"Free", "Pro", "Enterprise"}, form.WithLabel("Plan")),
+					form.Checkbox(c, f, "remember", "Remember me"),
+					button.New(c, "Log in", f.Submit, button.WithVariant(style.Primary)),
+				}
+			}, form.WithGapY(1)))
 		}
 
-		if formSubmit != nil {
+		if submitted != nil {
 			cs = append(cs,
-				text.New(c, "Email: "+formSubmit.email, nil),
-				text.New(c, "Password 🙈: "+formSubmit.password, nil),
-				text.New(c, "Remember me: "+formSubmit.remember, nil),
+				text.New(c, "Email: "+form.Get[string](submitted, "email")),
+				text.New(c, "Password 🙈: "+form.Get[string](submitted, "password")),
+				text.New(c, "Country: "+form.Get[string](submitted, "country")),
+				text.New(c, "Plan: "+form.Get[string](submitted, "plan")),
+				text.New(c, fmt.Sprintf("Remember me: %t", form.Get[bool](submitted, "remember"))),
 			)
 		}
 
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/component/button"
+	"github.com/alexanderbh/bubbleapp/component/divider"
+	"github.com/alexanderbh/bubbleapp/component/stack"
+	"github.com/alexanderbh/bubbleapp/component/text"
+	"github.com/alexanderbh/bubbleapp/style"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+func NewRoot(c *app.Ctx) *app.C {
+	confirming, setConfirming := app.UseState(c, false)
+	events, setEvents := app.UseState(c, []string{})
+
+	logEvent := func(event string) {
+		setEvents(func(prev []string) []string {
+			return append(prev[max(0, len(prev)-4):], event)
+		})
+	}
+
+	focused := app.UseAppFocus(c, func(focused bool) {
+		logEvent(fmt.Sprintf("Focused: %t", focused))
+	})
+	app.UseSuspend(c, func() {
+		logEvent("Suspended")
+	})
+	app.UseResume(c, func() {
+		logEvent("Resumed")
+	})
+	app.UseBeforeQuit(c, func() bool {
+		// Ask before quitting.
+		setConfirming(true)
+		return false
+	})
+	size := app.UseWindowSize(c)
+
+	return stack.New(c, func(c *app.Ctx) []*app.C {
+		children := []*app.C{
+			text.New(c, fmt.Sprintf("Window: %dx%d", size.Width, size.Height)),
+			text.New(c, fmt.Sprintf("Terminal has focus: %t", focused)),
+			text.New(c, "Press [ctrl+z] to suspend and [ctrl+c] to quit.", text.WithFg(c.Theme.Colors.Base400)),
+			divider.New(c),
+		}
+		for _, event := range events {
+			children = append(children, text.New(c, event))
+		}
+		if confirming {
+			children = append(children,
+				divider.New(c),
+				text.New(c, "Do you want to quit?", text.WithFg(c.Theme.Colors.Warning)),
+				stack.New(c, func(c *app.Ctx) []*app.C {
+					return []*app.C{
+						button.New(c, "Quit", func() {
+							c.ForceQuit()
+						}, button.WithVariant(style.Danger)),
+						button.New(c, "Cancel", func() {
+							setConfirming(false)
+						}),
+					}
+				}, stack.WithDirection(app.Horizontal), stack.WithGap(2), stack.WithGrowY(false)),
+			)
+		}
+		return children
+	})
+}
+
+func main() {
+	c := app.NewCtx()
+
+	bubbleApp := app.New(c, NewRoot)
+	p := tea.NewProgram(bubbleApp, tea.WithAltScreen(), tea.WithMouseAllMotion(), tea.WithReportFocus())
+	bubbleApp.SetTeaProgram(p)
+	if _, err := p.Run(); err != nil {
+		os.Exit(1)
+	}
+}
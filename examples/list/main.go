@@ -0,0 +1,54 @@
+package main
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/component/divider"
+	"github.com/alexanderbh/bubbleapp/component/list"
+	"github.com/alexanderbh/bubbleapp/component/stack"
+	"github.com/alexanderbh/bubbleapp/component/text"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+const numberOfLines = 100_000
+
+func NewRoot(c *app.Ctx) *app.C {
+	selected, setSelected := app.UseState(c, []int{})
+	activated, setActivated := app.UseState(c, -1)
+
+	return stack.New(c, func(c *app.Ctx) []*app.C {
+		return []*app.C{
+			text.New(c, "Selected: "+strconv.Itoa(len(selected))+" Activated: "+strconv.Itoa(activated)),
+			divider.New(c),
+			list.New(c, numberOfLines, func(c *app.Ctx, item list.Item) *app.C {
+				marker := "[ ] "
+				if item.Selected {
+					marker = "[x] "
+				}
+				return text.New(c, marker+"Log line number "+strconv.Itoa(item.Index))
+			},
+				list.WithMulti(true),
+				list.WithScrollbar(true),
+				list.WithOnSelectionChange(func(s []int) { setSelected(s) }),
+				list.WithOnActivate(func(index int) { setActivated(index) }),
+			),
+			divider.New(c),
+			text.New(c, "Press [space] to select, [enter] to activate and [ctrl-c] to quit.", text.WithFg(c.Theme.Colors.DangerFg)),
+		}
+	})
+}
+
+func main() {
+	c := app.NewCtx()
+
+	bubbleApp := app.New(c, NewRoot)
+	p := tea.NewProgram(bubbleApp, tea.WithAltScreen(), tea.WithMouseAllMotion())
+	bubbleApp.SetTeaProgram(p)
+
+	if _, err := p.Run(); err != nil {
+		os.Exit(1)
+	}
+}
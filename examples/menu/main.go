@@ -0,0 +1,81 @@
+package main
+
+import (
+	"os"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/component/button"
+	"github.com/alexanderbh/bubbleapp/component/menu"
+	"github.com/alexanderbh/bubbleapp/component/stack"
+	"github.com/alexanderbh/bubbleapp/component/text"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+func NewRoot(c *app.Ctx) *app.C {
+	last, setLast := app.UseState(c, "Nothing chosen yet")
+	sidebar, setSidebar := app.UseState(c, true)
+	wrap, setWrap := app.UseState(c, false)
+
+	chosen := func(label string) func() {
+		return func() { setLast("Chose " + label) }
+	}
+
+	menus := []menu.Menu{
+		{Title: "&File", Items: []menu.Item{
+			{Label: "&New", Shortcut: "ctrl+n", OnSelect: chosen("New")},
+			{Label: "&Open...", Shortcut: "ctrl+o", OnSelect: chosen("Open")},
+			{Label: "Open &Recent", Items: []menu.Item{
+				{Label: "&1 notes.md", OnSelect: chosen("notes.md")},
+				{Label: "&2 todo.txt", OnSelect: chosen("todo.txt")},
+			}},
+			menu.Separator(),
+			{Label: "&Save", Shortcut: "ctrl+s", OnSelect: chosen("Save")},
+			{Label: "Save &As...", Disabled: true},
+			menu.Separator(),
+			{Label: "&Quit", OnSelect: c.Quit},
+		}},
+		{Title: "&Edit", Items: []menu.Item{
+			{Label: "&Undo", Shortcut: "ctrl+z", Disabled: true},
+			{Label: "Cu&t", Shortcut: "ctrl+x", OnSelect: chosen("Cut")},
+			{Label: "&Copy", Shortcut: "ctrl+c", OnSelect: chosen("Copy")},
+			{Label: "&Paste", Shortcut: "ctrl+v", OnSelect: chosen("Paste")},
+		}},
+		{Title: "&View", Items: []menu.Item{
+			{Label: "&Sidebar", Checkable: true, Checked: sidebar, OnSelect: func() { setSidebar(!sidebar) }},
+			{Label: "&Word Wrap", Checkable: true, Checked: wrap, OnSelect: func() { setWrap(!wrap) }},
+			menu.Separator(),
+			{Label: "&Zoom", Items: []menu.Item{
+				{Label: "Zoom &In", OnSelect: chosen("Zoom In")},
+				{Label: "Zoom &Out", OnSelect: chosen("Zoom Out")},
+			}},
+		}},
+	}
+
+	return stack.New(c, func(c *app.Ctx) []*app.C {
+		return []*app.C{
+			menu.NewBar(c, menus),
+			text.New(c, "Use alt+f, alt+e, alt+v or f10 to open a menu."),
+			text.New(c, last),
+			menu.NewContext(c, func(c *app.Ctx) *app.C {
+				return button.New(c, "Right click me or press shift+f10", chosen("Button"))
+			}, []menu.Item{
+				{Label: "&Rename", OnSelect: chosen("Rename")},
+				{Label: "&Duplicate", OnSelect: chosen("Duplicate")},
+				menu.Separator(),
+				{Label: "De&lete", Disabled: true},
+			}),
+		}
+	})
+}
+
+func main() {
+	c := app.NewCtx()
+
+	bubbleApp := app.New(c, NewRoot)
+	p := tea.NewProgram(bubbleApp, tea.WithAltScreen(), tea.WithMouseAllMotion())
+	bubbleApp.SetTeaProgram(p)
+	if _, err := p.Run(); err != nil {
+		os.Exit(1)
+	}
+}
@@ -19,24 +19,32 @@ type authData struct {
 }
 
 type MainApp struct {
-	data    authData
-	setData func(authData)
+	data    *authData
+	setData func(*authData)
 }
 
 var AppDataContext = context.Create(MainApp{})
 
 func NewLoginRoot(c *app.Ctx) *app.C {
-	data, setData := app.UseState(c, authData{})
+	// The data is a pointer as the guard runs when the login route
+	// navigates, before the logged in user is rendered.
+	data, setData := app.UseState(c, &authData{})
 
 	mainApp := MainApp{
 		data:    data,
-		setData: func(ad authData) { setData(ad) },
+		setData: func(ad *authData) { setData(ad) },
 	}
 
 	return context.NewProvider(c, AppDataContext, mainApp, func(c *app.Ctx) *app.C {
 		return router.NewRouter(c, router.RouterProps{
 			Routes: []router.Route{
-				{Path: "/", Component: mainRoute},
+				{Path: "/", Component: mainRoute, BeforeEnter: func(router.Location) string {
+					// Only logged in users can see the main route.
+					if data.userID == "" {
+						return "/login"
+					}
+					return ""
+				}},
 				{Path: "/login", Component: loginRoute},
 			},
 		})
@@ -45,16 +53,6 @@ func NewLoginRoot(c *app.Ctx) *app.C {
 }
 
 func mainRoute(c *app.Ctx) *app.C {
-	// UseContext returns AppContextValue.
-	contextValue := context.UseContext(c, AppDataContext)
-	appAuthData := contextValue.data // This is *authData
-	router := router.UseRouterController(c)
-
-	if appAuthData.userID == "" {
-		router.ReplaceRoot(c, "/login")
-		return text.New(c, "No user logged in")
-	}
-
 	return NewAuthModel(c)
 }
 
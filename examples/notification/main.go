@@ -0,0 +1,57 @@
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/component/button"
+	"github.com/alexanderbh/bubbleapp/component/notification"
+	"github.com/alexanderbh/bubbleapp/component/stack"
+	"github.com/alexanderbh/bubbleapp/component/text"
+	"github.com/alexanderbh/bubbleapp/style"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+func NewRoot(c *app.Ctx) *app.C {
+	return notification.NewProvider(c, buttons, notification.WithCorner(app.BottomRight))
+}
+
+func buttons(c *app.Ctx) *app.C {
+	toast := notification.UseToast(c)
+
+	return stack.New(c, func(c *app.Ctx) []*app.C {
+		return []*app.C{
+			text.New(c, "Show a toast. Click a toast to dismiss it."),
+			button.New(c, "Save", func() { toast.Success("Saved") }, button.WithVariant(style.Success)),
+			button.New(c, "Disconnect", func() {
+				toast.Show(notification.Toast{
+					Title:    "Connection lost",
+					Message:  "Reconnecting in 5 seconds...",
+					Variant:  style.Danger,
+					Duration: -1,
+				})
+			}, button.WithVariant(style.Danger)),
+			button.New(c, "Info", func() { toast.Info("There is a new version available") }, button.WithVariant(style.Info)),
+			button.New(c, "Later", func() {
+				go func() {
+					time.Sleep(time.Second)
+					notification.Notify("Sent from a goroutine", style.Warning)
+				}()
+			}, button.WithVariant(style.Warning)),
+			button.New(c, "Clear", func() { toast.Clear() }),
+		}
+	})
+}
+
+func main() {
+	c := app.NewCtx()
+
+	bubbleApp := app.New(c, NewRoot)
+	p := tea.NewProgram(bubbleApp, tea.WithAltScreen(), tea.WithMouseAllMotion())
+	bubbleApp.SetTeaProgram(p)
+	if _, err := p.Run(); err != nil {
+		os.Exit(1)
+	}
+}
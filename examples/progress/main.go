@@ -0,0 +1,65 @@
+package main
+
+import (
+	"math"
+	"os"
+	"time"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/component/button"
+	"github.com/alexanderbh/bubbleapp/component/divider"
+	"github.com/alexanderbh/bubbleapp/component/progress"
+	"github.com/alexanderbh/bubbleapp/component/stack"
+	"github.com/alexanderbh/bubbleapp/component/text"
+	"github.com/alexanderbh/bubbleapp/style"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+func NewRoot(c *app.Ctx) *app.C {
+	download, setDownload := app.UseState(c, 0.0)
+	step, setStep := app.UseState(c, 0)
+
+	app.UseTick(c, 200*time.Millisecond, func() {
+		setDownload(func(prev float64) float64 { return min(1, prev+0.01) })
+		setStep(func(prev int) int { return prev + 1 })
+	})
+
+	load := (math.Sin(float64(step)/10) + 1) / 2
+
+	return stack.New(c, func(c *app.Ctx) []*app.C {
+		return []*app.C{
+			text.New(c, "Download"),
+			progress.New(c, download, progress.WithETA(true)),
+			text.New(c, "Animated"),
+			progress.New(c, math.Round(download*4)/4, progress.WithAnimate(true), progress.WithVariant(style.Success)),
+			text.New(c, "Disk usage"),
+			progress.NewStacked(c, []progress.Segment{
+				{Value: 0.32, Variant: style.Primary},
+				{Value: 0.18, Variant: style.Secondary},
+				{Value: 0.21, Variant: style.Warning},
+			}),
+			divider.New(c),
+			stack.New(c, func(c *app.Ctx) []*app.C {
+				return []*app.C{
+					progress.NewGauge(c, load, progress.WithAnimate(true)),
+					progress.NewGauge(c, download, progress.WithVariant(style.Success)),
+					progress.NewGauge(c, 1-load, progress.WithWidth(6), progress.WithVariant(style.Danger)),
+				}
+			}, stack.WithDirection(app.Horizontal), stack.WithGap(2)),
+			divider.New(c),
+			button.New(c, "Restart", func() { setDownload(0.0) }),
+		}
+	})
+}
+
+func main() {
+	c := app.NewCtx()
+
+	bubbleApp := app.New(c, NewRoot)
+	p := tea.NewProgram(bubbleApp, tea.WithAltScreen(), tea.WithMouseAllMotion())
+	bubbleApp.SetTeaProgram(p)
+	if _, err := p.Run(); err != nil {
+		os.Exit(1)
+	}
+}
@@ -1,32 +1,56 @@
 package main
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/alexanderbh/bubbleapp/app"
 	"github.com/alexanderbh/bubbleapp/component/box"
 	"github.com/alexanderbh/bubbleapp/component/button"
 	"github.com/alexanderbh/bubbleapp/component/divider"
+	"github.com/alexanderbh/bubbleapp/component/list"
 	"github.com/alexanderbh/bubbleapp/component/router"
 	"github.com/alexanderbh/bubbleapp/component/stack"
 	"github.com/alexanderbh/bubbleapp/component/text"
+	"github.com/alexanderbh/bubbleapp/component/transition"
 )
 
 func MainRouter(c *app.Ctx) *app.C {
+	unsaved, setUnsaved := app.UseState(c, false)
+	leaving, setLeaving := app.UseState(c, "")
+
+	settings := func(c *app.Ctx) *app.C {
+		return accountSettings(c, unsaved, setUnsaved, leaving, setLeaving)
+	}
+
 	return router.NewRouter(c, router.RouterProps{
 		Routes: []router.Route{
 			{Path: "/", Component: dashboard},
-			{Path: "/shop", Component: shop},
+			{Path: "/shop/:item?", Component: shop},
 
 			{Path: "/account", Component: account, Children: []router.Route{
 				{Path: "/overview", Component: accountOverview},
-				{Path: "/settings", Component: accountSettings},
-				{Path: "/orders", Component: accountOrders},
+				{Path: "/settings", Component: settings, BeforeLeave: func(from, to router.Location) bool {
+					// Ask before leaving the settings with unsaved changes.
+					if unsaved {
+						setLeaving(to.URL)
+						return false
+					}
+					return true
+				}},
+				// The orders keep their cursor and scroll position while
+				// another page is shown.
+				{Path: "/orders", Component: accountOrders, KeepAlive: true},
 			}},
+
+			{Path: "*", Component: notFound},
 		},
+		Transition: &transition.Effect{Kind: transition.SlideLeft, Duration: 250 * time.Millisecond, Easing: app.EaseOut},
 	})
 }
 
 func dashboard(c *app.Ctx) *app.C {
-	router := router.UseRouterController(c)
+	r := router.UseRouterController(c)
 
 	return stack.New(c, func(c *app.Ctx) []*app.C {
 		return []*app.C{
@@ -36,18 +60,25 @@ func dashboard(c *app.Ctx) *app.C {
 			divider.New(c),
 
 			button.New(c, "Shop", func() {
-				router.Push(c, "/shop")
+				r.Push(c, "/shop")
 			}),
 
 			button.New(c, "My Account", func() {
-				router.Push(c, "/account/overview")
+				r.Push(c, "/account/overview")
 			}),
+
+			router.NewLink(c, "Broken link", "/does/not/exist"),
 		}
 	})
 }
 
 func account(c *app.Ctx) *app.C {
 	r := router.UseRouterController(c)
+	lastEvent, setLastEvent := app.UseState(c, "")
+
+	router.UseNavigationEvents(c, func(e router.NavigationEvent) {
+		setLastEvent(e.Kind.String() + " from " + e.From.URL + " to " + e.To.URL)
+	})
 
 	return stack.New(c, func(c *app.Ctx) []*app.C {
 		return []*app.C{
@@ -67,6 +98,14 @@ func account(c *app.Ctx) *app.C {
 				}
 			}, stack.WithDirection(app.Horizontal), stack.WithGap(3), stack.WithGrowY(false)),
 
+			stack.New(c, func(c *app.Ctx) []*app.C {
+				return []*app.C{
+					button.New(c, "← Back", func() { r.Back(c) }),
+					button.New(c, "Forward →", func() { r.Forward(c) }),
+					text.New(c, lastEvent, text.WithFg(c.Theme.Colors.Base400)),
+				}
+			}, stack.WithDirection(app.Horizontal), stack.WithGap(1), stack.WithGrowY(false)),
+
 			divider.New(c),
 
 			router.NewOutlet(c),
@@ -84,15 +123,67 @@ func accountOverview(c *app.Ctx) *app.C {
 	return text.New(c, "Account Overview")
 }
 
-func accountSettings(c *app.Ctx) *app.C {
-	return text.New(c, "Account Settings")
+func accountSettings(c *app.Ctx, unsaved bool, setUnsaved func(any), leaving string, setLeaving func(any)) *app.C {
+	r := router.UseRouterController(c)
+
+	return stack.New(c, func(c *app.Ctx) []*app.C {
+		if leaving != "" {
+			return []*app.C{
+				text.New(c, "You have unsaved changes.", text.WithFg(c.Theme.Colors.Warning)),
+				button.New(c, "Discard changes", func() {
+					setUnsaved(false)
+					setLeaving("")
+					r.Navigate(c, leaving, router.WithForce(true))
+				}),
+				button.New(c, "Stay", func() {
+					setLeaving("")
+				}),
+			}
+		}
+		status := "No changes"
+		if unsaved {
+			status = "Unsaved changes"
+		}
+		return []*app.C{
+			text.New(c, "Account Settings - "+status),
+			button.New(c, "Change something", func() {
+				setUnsaved(true)
+			}),
+		}
+	}, stack.WithGrowY(false))
 }
 func accountOrders(c *app.Ctx) *app.C {
-	return text.New(c, "Account Orders")
+	visits, setVisits := app.UseState(c, 0)
+
+	router.UseActivated(c, func() {
+		setVisits(visits + 1)
+	})
+
+	return stack.New(c, func(c *app.Ctx) []*app.C {
+		return []*app.C{
+			text.New(c, fmt.Sprintf("Account Orders - visit %d", visits)),
+			list.New(c, 50, func(c *app.Ctx, item list.Item) *app.C {
+				return text.New(c, fmt.Sprintf("Order #%d", 1000+item.Index))
+			}, list.WithScrollbar(true)),
+		}
+	})
 }
 
 func shop(c *app.Ctx) *app.C {
-	router := router.UseRouterController(c)
+	match := router.UseCurrentMatch(c)
+	r := router.UseRouterController(c)
+
+	if item := match.PathParams["item"]; item != "" {
+		price, _ := router.UseNavigationState[int](c)
+		return stack.New(c, func(c *app.Ctx) []*app.C {
+			return []*app.C{
+				text.New(c, fmt.Sprintf("Shop Item %s in %s for $%d", item, match.Query.Get("color"), price)),
+				button.New(c, "Back to the Shop", func() {
+					r.Push(c, "/shop")
+				}),
+			}
+		})
+	}
 
 	return stack.New(c, func(c *app.Ctx) []*app.C {
 		return []*app.C{
@@ -102,16 +193,29 @@ func shop(c *app.Ctx) *app.C {
 			divider.New(c),
 
 			button.New(c, "Back to Dashboard", func() {
-				router.Push(c, "/")
+				r.Push(c, "/")
 			}),
 
 			stack.New(c, func(c *app.Ctx) []*app.C {
 				return []*app.C{
-					text.New(c, "Shop Item 1 - $10", text.WithFg(c.Theme.Colors.Tertiary)),
-					text.New(c, "Shop Item 2 - $12", text.WithFg(c.Theme.Colors.Tertiary)),
-					text.New(c, "This is just for show", text.WithFg(c.Theme.Colors.Warning)),
+					router.NewLink(c, "Shop Item 1 - $10", "/shop/1?color=red", router.WithNavigateOptions(router.WithState(10))),
+					router.NewLink(c, "Shop Item 2 - $12", "/shop/2?color=blue", router.WithNavigateOptions(router.WithState(12))),
 				}
 			}),
 		}
 	})
 }
+
+func notFound(c *app.Ctx) *app.C {
+	match := router.UseCurrentMatch(c)
+	r := router.UseRouterController(c)
+
+	return stack.New(c, func(c *app.Ctx) []*app.C {
+		return []*app.C{
+			text.New(c, "Nothing at /"+match.PathParams["*"], text.WithFg(c.Theme.Colors.Danger)),
+			button.New(c, "Back to Dashboard", func() {
+				r.Push(c, "/")
+			}),
+		}
+	})
+}
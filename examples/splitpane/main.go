@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/component/box"
+	"github.com/alexanderbh/bubbleapp/component/splitpane"
+	"github.com/alexanderbh/bubbleapp/component/text"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+func NewRoot(c *app.Ctx) *app.C {
+	return splitpane.New(c, files, func(c *app.Ctx) *app.C {
+		return splitpane.New(c, logs, details,
+			splitpane.WithDirection(app.Vertical),
+			splitpane.WithRatio(0.7),
+			splitpane.WithMinSizes(3, 3),
+		)
+	}, splitpane.WithRatio(0.3), splitpane.WithMinSizes(10, 20))
+}
+
+func files(c *app.Ctx) *app.C {
+	return text.New(c, "Drag the dividers with the mouse\nor focus them with tab and use\nthe arrow keys. home/end collapses\nand enter expands again.")
+}
+
+func logs(c *app.Ctx) *app.C {
+	lines := make([]string, 0, 100)
+	for i := range 100 {
+		lines = append(lines, "["+strconv.Itoa(i)+"] log line")
+	}
+	return box.New(c, func(c *app.Ctx) *app.C {
+		return text.New(c, strings.Join(lines, "\n"))
+	})
+}
+
+func details(c *app.Ctx) *app.C {
+	return box.New(c, func(c *app.Ctx) *app.C {
+		return text.New(c, "Details", text.WithFg(c.Theme.Colors.Base950))
+	}, box.WithBg(c.Theme.Colors.PrimaryLight))
+}
+
+func main() {
+	c := app.NewCtx()
+
+	bubbleApp := app.New(c, NewRoot)
+	p := tea.NewProgram(bubbleApp, tea.WithAltScreen(), tea.WithMouseAllMotion())
+	bubbleApp.SetTeaProgram(p)
+
+	if _, err := p.Run(); err != nil {
+		os.Exit(1)
+	}
+}
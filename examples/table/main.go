@@ -8,6 +8,7 @@ import (
 	"github.com/alexanderbh/bubbleapp/app"
 	"github.com/alexanderbh/bubbleapp/component/stack"
 	"github.com/alexanderbh/bubbleapp/component/table"
+	"github.com/alexanderbh/bubbleapp/style"
 
 	tea "github.com/charmbracelet/bubbletea/v2"
 )
@@ -19,14 +20,22 @@ func NewRoot(c *app.Ctx) *app.C {
 		return []*app.C{
 			table.New(c, table.WithDataFunc(func(c *app.Ctx) ([]table.Column, []table.Row) {
 				return clms, rows
-			})),
+			}), table.WithCellFunc(populationCell)),
 			table.New(c, table.WithDataFunc(func(c *app.Ctx) ([]table.Column, []table.Row) {
 				return clms, rows
-			})),
+			}), table.WithCheckbox(true)),
 		}
 	}, stack.WithDirection(app.Horizontal))
 }
 
+// populationCell highlights the cities with more than 20 million people.
+func populationCell(c *app.Ctx, row, column int, value string) table.Cell {
+	if column != 3 || table.CompareNumbers(value, "20,000,000") < 0 {
+		return table.Cell{}
+	}
+	return table.TextCell(value).WithVariant(style.Warning)
+}
+
 func main() {
 	// pprof - used for debugging performance - just ignore
 	go func() {
@@ -44,10 +53,10 @@ func main() {
 }
 
 var clms = []table.Column{
-	{Title: "Rank", Width: table.WidthInt(4)},
+	{Title: "Rank", Width: table.WidthInt(6), Compare: table.CompareNumbers, Align: table.AlignRight},
 	{Title: "City", Width: table.WidthGrow()},
 	{Title: "Country", Width: table.WidthGrow()},
-	{Title: "Population", Width: table.WidthInt(10)},
+	{Title: "Population", Width: table.WidthInt(12), Compare: table.CompareNumbers, Align: table.AlignRight},
 }
 
 var rows = []table.Row{
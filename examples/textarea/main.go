@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/component/stack"
+	"github.com/alexanderbh/bubbleapp/component/text"
+	"github.com/alexanderbh/bubbleapp/component/textarea"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+const poem = `Whose woods these are I think I know. His house is in the village though; He will not see me stopping here To watch his woods fill up with snow.
+My little horse must think it queer To stop without a farmhouse near Between the woods and frozen lake The darkest evening of the year.`
+
+func NewRoot(c *app.Ctx) *app.C {
+	notes, setNotes := app.UseState(c, poem)
+	message, setMessage := app.UseState(c, "")
+
+	return stack.New(c, func(c *app.Ctx) []*app.C {
+		return []*app.C{
+			textarea.New(c, func(text string) {
+				setNotes(text)
+			}, notes, textarea.WithLineNumbers(true), textarea.WithGrowY(true)),
+			textarea.New(c, func(text string) {
+				setMessage(text)
+			}, message, textarea.WithPlaceholder("Write a message..."), textarea.WithHeight(6)),
+			text.New(c, fmt.Sprintf("Notes: %d lines, %d characters. Message: %d characters.",
+				strings.Count(notes, "\n")+1, len([]rune(notes)), len([]rune(message)))),
+			text.New(c, "[shift+arrows] select, [ctrl+z] undo, [ctrl+y] redo, [tab] next, [ctrl-c] quit.", text.WithFg(c.Theme.Colors.DangerFg)),
+		}
+	})
+}
+
+func main() {
+	c := app.NewCtx()
+
+	bubbleApp := app.New(c, NewRoot)
+	p := tea.NewProgram(bubbleApp, tea.WithAltScreen(), tea.WithMouseAllMotion())
+	bubbleApp.SetTeaProgram(p)
+	if _, err := p.Run(); err != nil {
+		os.Exit(1)
+	}
+}
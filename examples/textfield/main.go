@@ -1,7 +1,9 @@
 package main
 
 import (
+	"errors"
 	"os"
+	"strings"
 
 	"github.com/alexanderbh/bubbleapp/app"
 	"github.com/alexanderbh/bubbleapp/component/button"
@@ -14,12 +16,15 @@ import (
 	tea "github.com/charmbracelet/bubbletea/v2"
 )
 
-type CustomData struct{}
+var languages = []string{"Go", "Haskell", "JavaScript", "Python", "Rust", "TypeScript", "Zig"}
 
 func NewRoot(c *app.Ctx) *app.C {
 
 	textValue, setTextValue := app.UseState(c, "")
 	textValue2, setTextValue2 := app.UseState(c, "")
+	password, setPassword := app.UseState(c, "")
+	language, setLanguage := app.UseState(c, "")
+	username, setUsername := app.UseState(c, "")
 
 	return stack.New(c, func(c *app.Ctx) []*app.C {
 		return []*app.C{
@@ -27,14 +32,31 @@ func NewRoot(c *app.Ctx) *app.C {
 				return []*app.C{
 					textfield.New(c, func(text string) {
 						setTextValue(text)
-					}, textValue, textfield.WithTitle("Type something:")),
+					}, textValue, textfield.WithTitle("Type something:"), textfield.WithPlaceholder("Anything")),
 					textfield.New(c, func(text string) {
 						setTextValue2(text)
 					}, textValue2, textfield.WithTitle("Or here?\nPerhaps:")),
 				}
 			}, stack.WithDirection(app.Horizontal)),
+			textfield.New(c, func(text string) {
+				setUsername(text)
+			}, username, textfield.WithTitle("Username (max 12):"), textfield.WithCharLimit(12),
+				textfield.WithValidate(func(text string) error {
+					if strings.Contains(text, " ") {
+						return errors.New("No spaces allowed")
+					}
+					return nil
+				})),
+			textfield.New(c, func(text string) {
+				setPassword(text)
+			}, password, textfield.WithTitle("Password:"), textfield.WithEchoMode(textfield.EchoPassword)),
+			textfield.New(c, func(text string) {
+				setLanguage(text)
+			}, language, textfield.WithTitle("Favorite language ([tab] to complete):"), textfield.WithSuggestions(languages)),
+			textfield.New(c, nil, "Read only, but the cursor moves", textfield.WithTitle("Read only:"), textfield.WithReadOnly(true)),
+			textfield.New(c, nil, "Skipped when tabbing", textfield.WithTitle("Disabled:"), textfield.WithDisabled(true)),
 			divider.New(c),
-			text.New(c, "You typed: "+textValue, text.WithMB(2)),
+			text.New(c, "You typed: "+textValue, text.WithMB(1)),
 			text.New(c, "Press [ctrl-c] to quit."),
 			button.New(c, "Quit", c.Quit, button.WithVariant(style.Danger)),
 		}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/component/button"
+	"github.com/alexanderbh/bubbleapp/component/stack"
+	"github.com/alexanderbh/bubbleapp/component/text"
+	"github.com/alexanderbh/bubbleapp/component/tooltip"
+	"github.com/alexanderbh/bubbleapp/style"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+func NewRoot(c *app.Ctx) *app.C {
+	return stack.New(c, func(c *app.Ctx) []*app.C {
+		return []*app.C{
+			text.New(c, "Hover or tab to a button to show its tooltip."),
+			tooltip.New(c, func(c *app.Ctx) *app.C {
+				return button.New(c, "Save", func() {}, button.WithVariant(style.Success))
+			}, "Save the document"),
+			tooltip.New(c, func(c *app.Ctx) *app.C {
+				return button.New(c, "Delete", func() {}, button.WithVariant(style.Danger))
+			}, "This cannot be undone", tooltip.WithPlacement(app.Right)),
+			tooltip.New(c, func(c *app.Ctx) *app.C {
+				return button.New(c, "Details", func() {})
+			}, "", tooltip.WithContent(func(c *app.Ctx) *app.C {
+				return stack.New(c, func(c *app.Ctx) []*app.C {
+					return []*app.C{
+						text.New(c, "Size: 12 KB"),
+						text.New(c, "Modified: today"),
+					}
+				}, stack.WithGrowY(false))
+			}), tooltip.WithContentWidth(20), tooltip.WithPlacement(app.Right), tooltip.WithDelay(0)),
+		}
+	})
+}
+
+func main() {
+	c := app.NewCtx()
+
+	bubbleApp := app.New(c, NewRoot)
+	p := tea.NewProgram(bubbleApp, tea.WithAltScreen(), tea.WithMouseAllMotion())
+	bubbleApp.SetTeaProgram(p)
+	if _, err := p.Run(); err != nil {
+		os.Exit(1)
+	}
+}
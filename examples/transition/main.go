@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/component/button"
+	"github.com/alexanderbh/bubbleapp/component/divider"
+	"github.com/alexanderbh/bubbleapp/component/stack"
+	"github.com/alexanderbh/bubbleapp/component/text"
+	"github.com/alexanderbh/bubbleapp/component/transition"
+	"github.com/alexanderbh/bubbleapp/style"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+var kinds = []struct {
+	name string
+	kind transition.Kind
+}{
+	{"Fade", transition.Fade},
+	{"Slide left", transition.SlideLeft},
+	{"Slide right", transition.SlideRight},
+	{"Slide up", transition.SlideUp},
+	{"Slide down", transition.SlideDown},
+	{"Wipe", transition.Wipe},
+}
+
+var variants = []style.Variant{style.Primary, style.Success, style.Warning, style.Danger}
+
+func NewRoot(c *app.Ctx) *app.C {
+	kind, setKind := app.UseState(c, 0)
+	page, setPage := app.UseState(c, 0)
+	show, setShow := app.UseState(c, true)
+
+	return stack.New(c, func(c *app.Ctx) []*app.C {
+		return []*app.C{
+			text.New(c, "Transition: "+kinds[kind].name),
+			stack.New(c, func(c *app.Ctx) []*app.C {
+				return []*app.C{
+					button.New(c, "Next kind", func() {
+						setKind((kind + 1) % len(kinds))
+					}),
+					button.New(c, "Next page", func() {
+						setPage(page + 1)
+					}, button.WithVariant(style.Secondary)),
+					button.New(c, "Show/Hide", func() {
+						setShow(!show)
+					}, button.WithVariant(style.Tertiary)),
+				}
+			}, stack.WithDirection(app.Horizontal), stack.WithGap(2), stack.WithGrowY(false)),
+			divider.New(c),
+			transition.New(c, func(c *app.Ctx) *app.C {
+				return pageContent(c, page)
+			},
+				transition.WithKind(kinds[kind].kind),
+				transition.WithDuration(400*time.Millisecond),
+				transition.WithTrigger(page),
+				transition.WithShow(show),
+			),
+			divider.New(c),
+			c.Render(growingBar, nil),
+		}
+	})
+}
+
+func pageContent(c *app.Ctx, page int) *app.C {
+	variant := variants[page%len(variants)]
+	return stack.New(c, func(c *app.Ctx) []*app.C {
+		return []*app.C{
+			text.New(c, fmt.Sprintf("Page %d", page+1), text.WithVariant(variant)),
+			text.New(c, strings.Repeat("▒", 30), text.WithVariant(variant)),
+			text.New(c, "The content comes in with the chosen transition."),
+		}
+	})
+}
+
+// growingBar is a custom component which grows in with the progress of its
+// transition when it is mounted.
+func growingBar(c *app.Ctx, _ app.Props) string {
+	progress := app.UseTransition(c, nil, 2*time.Second)
+	width, _ := app.UseSize(c)
+	filled := int(app.EaseInOut(progress) * float64(width))
+	return c.Theme.Text[style.Success][style.Normal].Render(strings.Repeat("█", filled))
+}
+
+func main() {
+	c := app.NewCtx()
+
+	bubbleApp := app.New(c, NewRoot)
+	p := tea.NewProgram(bubbleApp, tea.WithAltScreen(), tea.WithMouseAllMotion())
+	bubbleApp.SetTeaProgram(p)
+	if _, err := p.Run(); err != nil {
+		os.Exit(1)
+	}
+}
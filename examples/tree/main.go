@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/alexanderbh/bubbleapp/app"
+	"github.com/alexanderbh/bubbleapp/component/divider"
+	"github.com/alexanderbh/bubbleapp/component/stack"
+	"github.com/alexanderbh/bubbleapp/component/text"
+	"github.com/alexanderbh/bubbleapp/component/tree"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// readDir lists a directory as lazy tree nodes. The full path is kept in Data.
+func readDir(dir string) []tree.Node {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return []tree.Node{{Label: err.Error()}}
+	}
+	nodes := make([]tree.Node, 0, len(entries))
+	for _, entry := range entries {
+		nodes = append(nodes, tree.Node{
+			Label: entry.Name(),
+			Lazy:  entry.IsDir(),
+			Data:  filepath.Join(dir, entry.Name()),
+		})
+	}
+	return nodes
+}
+
+func NewRoot(c *app.Ctx) *app.C {
+	selected, setSelected := app.UseState(c, "")
+	activated, setActivated := app.UseState(c, "")
+
+	cwd, _ := os.Getwd()
+	nodes := []tree.Node{{Label: filepath.Base(cwd), Lazy: true, Expanded: true, Data: cwd}}
+
+	return stack.New(c, func(c *app.Ctx) []*app.C {
+		return []*app.C{
+			text.New(c, "Selected: "+selected),
+			text.New(c, "Opened: "+activated),
+			divider.New(c),
+			tree.New(c, nodes,
+				tree.WithLoadChildren(func(item tree.Item) []tree.Node {
+					return readDir(item.Node.Data.(string))
+				}),
+				tree.WithOnSelect(func(item tree.Item) { setSelected(item.Path) }),
+				tree.WithOnActivate(func(item tree.Item) { setActivated(item.Node.Data.(string)) }),
+			),
+			divider.New(c),
+			text.New(c, "Use the arrows to move, expand and collapse, type to jump and [enter] to open.", text.WithFg(c.Theme.Colors.DangerFg)),
+		}
+	})
+}
+
+func main() {
+	c := app.NewCtx()
+
+	bubbleApp := app.New(c, NewRoot)
+	p := tea.NewProgram(bubbleApp, tea.WithAltScreen(), tea.WithMouseAllMotion())
+	bubbleApp.SetTeaProgram(p)
+
+	if _, err := p.Run(); err != nil {
+		os.Exit(1)
+	}
+}
@@ -2,6 +2,7 @@ package style
 
 import (
 	"image/color"
+	"reflect"
 )
 
 type Colors struct {
@@ -177,3 +178,40 @@ func NewColors(palette Palette) Colors {
 		WarningFg:      palette.Amber300,
 	}
 }
+
+// Blend mixes the two colors. The amount is how much of the second color
+// there is, from 0 to 1.
+func Blend(a, b color.Color, amount float64) color.Color {
+	if a == nil || b == nil {
+		return a
+	}
+	amount = max(0, min(amount, 1))
+	ar, ag, ab, aa := a.RGBA()
+	br, bg, bb, ba := b.RGBA()
+	mix := func(x, y uint32) uint16 {
+		return uint16(float64(x) + (float64(y)-float64(x))*amount)
+	}
+	return color.RGBA64{R: mix(ar, br), G: mix(ag, bg), B: mix(ab, bb), A: mix(aa, ba)}
+}
+
+// Fade returns the colors blended towards the color, like the background
+// when content fades in or out. The amount is from 0 to 1.
+func (c Colors) Fade(to color.Color, amount float64) Colors {
+	fadeFields(reflect.ValueOf(&c).Elem(), to, amount)
+	return c
+}
+
+// fadeFields blends every color field of the struct, also in the structs in
+// it like the Palette.
+func fadeFields(v reflect.Value, to color.Color, amount float64) {
+	colorType := reflect.TypeFor[color.Color]()
+	for i := range v.NumField() {
+		field := v.Field(i)
+		switch {
+		case field.Kind() == reflect.Struct:
+			fadeFields(field, to, amount)
+		case field.Type() == colorType && !field.IsNil():
+			field.Set(reflect.ValueOf(Blend(field.Interface().(color.Color), to, amount)))
+		}
+	}
+}
@@ -36,6 +36,120 @@ type AppTheme struct {
 
 	Button map[Variant]map[ComponentState]lipgloss.Style
 	Text   map[Variant]map[ComponentState]lipgloss.Style
+	// Checkbox styles the marks of checkboxes and radio buttons.
+	Checkbox map[Variant]map[ComponentState]lipgloss.Style
+	// Toggle styles the track of a toggle switch that is on. The Base variant
+	// is used for switches that are off.
+	Toggle   map[Variant]map[ComponentState]lipgloss.Style
+	Dropdown DropdownTheme
+	Progress ProgressTheme
+	Chart    ChartTheme
+	Tooltip  lipgloss.Style
+	Menu     MenuTheme
+
+	SplitPane SplitPaneTheme
+	List      ListTheme
+	Tree      TreeTheme
+	TextArea  TextAreaTheme
+	// Toast is the box around a notification. Its border is colored with the
+	// variant of the notification.
+	Toast lipgloss.Style
+	// Selection highlights the text selected with the mouse and the label
+	// shown while selecting.
+	Selection lipgloss.Style
+}
+
+// DropdownTheme styles the select, combobox and multiselect components.
+type DropdownTheme struct {
+	Field       map[ComponentState]lipgloss.Style
+	Placeholder lipgloss.Style
+	Indicator   lipgloss.Style
+	Chip        lipgloss.Style
+	// Menu is the box around the options shown below the field.
+	Menu           lipgloss.Style
+	Option         lipgloss.Style
+	OptionCursor   lipgloss.Style
+	OptionSelected lipgloss.Style
+	OptionDisabled lipgloss.Style
+}
+
+// MenuTheme styles the menubar and the menus opened from it or as context
+// menus.
+type MenuTheme struct {
+	Bar         lipgloss.Style
+	Title       lipgloss.Style
+	TitleActive lipgloss.Style
+	// Menu is the box around the items of a menu.
+	Menu         lipgloss.Style
+	Item         lipgloss.Style
+	ItemCursor   lipgloss.Style
+	ItemDisabled lipgloss.Style
+	Separator    lipgloss.Style
+	// Accelerator marks the letter which chooses the item or opens the menu.
+	Accelerator lipgloss.Style
+	Shortcut    lipgloss.Style
+}
+
+// SplitPaneTheme styles the divider between the panes of a split pane.
+type SplitPaneTheme struct {
+	Divider        lipgloss.Style
+	DividerHover   lipgloss.Style
+	DividerFocus   lipgloss.Style
+	DividerActive  lipgloss.Style
+	HorizontalChar string // Used when the panes are side by side
+	VerticalChar   string // Used when the panes are stacked
+}
+
+// ListTheme styles the items and the scrollbar of a list.
+type ListTheme struct {
+	Item        lipgloss.Style
+	Cursor      lipgloss.Style
+	CursorFocus lipgloss.Style
+	Selected    lipgloss.Style
+	Hovered     lipgloss.Style
+	Scrollbar   lipgloss.Style
+	ScrollThumb lipgloss.Style
+}
+
+// TreeTheme styles the nodes of a tree and the guides connecting them.
+type TreeTheme struct {
+	Node        lipgloss.Style
+	Cursor      lipgloss.Style
+	CursorFocus lipgloss.Style
+	Hovered     lipgloss.Style
+	Guide       lipgloss.Style
+	// Indicator is the arrow of branches and the text shown while the
+	// children of a node load.
+	Indicator lipgloss.Style
+}
+
+// TextAreaTheme styles the multi-line text input.
+type TextAreaTheme struct {
+	Base             lipgloss.Style
+	BaseFocus        lipgloss.Style
+	Text             lipgloss.Style
+	Placeholder      lipgloss.Style
+	Selection        lipgloss.Style
+	LineNumber       lipgloss.Style
+	CursorLineNumber lipgloss.Style // The number of the line with the cursor
+}
+
+// ProgressTheme styles the progress bars and gauges.
+type ProgressTheme struct {
+	// Fill colors the done part of each variant with its foreground.
+	Fill map[Variant]lipgloss.Style
+	// Track colors the part that is not done with its background.
+	Track lipgloss.Style
+	Label lipgloss.Style
+}
+
+// ChartTheme styles the sparkline, line, bar and histogram charts.
+type ChartTheme struct {
+	// Series colors the data of each variant with its foreground.
+	Series  map[Variant]lipgloss.Style
+	Axis    lipgloss.Style
+	Label   lipgloss.Style
+	Tooltip lipgloss.Style
 }
 
 func NewDefaultAppTheme() *AppTheme {
@@ -47,6 +161,40 @@ func NewAppTheme(colors Colors) *AppTheme {
 	// Define a base padding for buttons
 	buttonPadding := lipgloss.NewStyle()
 
+	// The main and the highlighted color of each variant used by the
+	// checkbox, radio and toggle components.
+	accents := map[Variant][2]color.Color{
+		Primary:   {colors.Primary, colors.PrimaryLight},
+		Secondary: {colors.Secondary, colors.SecondaryLight},
+		Tertiary:  {colors.Tertiary, colors.TertiaryLight},
+		Success:   {colors.Success, colors.SuccessLight},
+		Danger:    {colors.Danger, colors.DangerLight},
+		Info:      {colors.Info, colors.InfoLight},
+		Warning:   {colors.WarningDark, colors.WarningLight},
+		Base:      {colors.Base600, colors.Base500},
+	}
+	checkbox := make(map[Variant]map[ComponentState]lipgloss.Style, len(accents))
+	toggle := make(map[Variant]map[ComponentState]lipgloss.Style, len(accents))
+	fill := make(map[Variant]lipgloss.Style, len(accents))
+	for variant, accent := range accents {
+		fill[variant] = lipgloss.NewStyle().Foreground(accent[0])
+		checkbox[variant] = map[ComponentState]lipgloss.Style{
+			Normal:   lipgloss.NewStyle().Foreground(accent[0]),
+			Hover:    lipgloss.NewStyle().Foreground(accent[1]),
+			Focus:    lipgloss.NewStyle().Foreground(accent[1]).Bold(true),
+			Disabled: lipgloss.NewStyle().Foreground(colors.Base600),
+		}
+		toggle[variant] = map[ComponentState]lipgloss.Style{
+			Normal:   lipgloss.NewStyle().Background(accent[0]).Foreground(colors.Base50),
+			Hover:    lipgloss.NewStyle().Background(accent[1]).Foreground(colors.Base950),
+			Focus:    lipgloss.NewStyle().Background(accent[1]).Foreground(colors.Base950).Bold(true),
+			Disabled: lipgloss.NewStyle().Background(colors.Base800).Foreground(colors.Base600),
+		}
+	}
+
+	textArea := lipgloss.NewStyle().Border(lipgloss.NormalBorder(), true, true, true, true).BorderForeground(colors.Base600)
+	tooltip := lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(colors.Base600).Background(colors.Base900).Foreground(colors.Base50).Padding(0, 1)
+
 	return &AppTheme{
 		Colors:          colors,
 		BackgroundColor: colors.Base900,
@@ -152,5 +300,83 @@ func NewAppTheme(colors Colors) *AppTheme {
 				Disabled: lipgloss.NewStyle().Foreground(colors.Base400),
 			},
 		},
+		Checkbox: checkbox,
+		Toggle:   toggle,
+		Dropdown: DropdownTheme{
+			Field: map[ComponentState]lipgloss.Style{
+				Normal:   lipgloss.NewStyle().Background(colors.Base800).Foreground(colors.Base50),
+				Hover:    lipgloss.NewStyle().Background(colors.Base700).Foreground(colors.Base50),
+				Focus:    lipgloss.NewStyle().Background(colors.Base700).Foreground(colors.PrimaryLight),
+				Disabled: lipgloss.NewStyle().Background(colors.Base800).Foreground(colors.Base500),
+			},
+			Placeholder:    lipgloss.NewStyle().Foreground(colors.Base400),
+			Indicator:      lipgloss.NewStyle().Foreground(colors.Base400),
+			Chip:           lipgloss.NewStyle().Background(colors.PrimaryDark).Foreground(colors.Base50),
+			Menu:           lipgloss.NewStyle().Border(lipgloss.NormalBorder()).BorderForeground(colors.Base600).Background(colors.Base900),
+			Option:         lipgloss.NewStyle().Foreground(colors.Base50),
+			OptionCursor:   lipgloss.NewStyle().Background(colors.Base700).Foreground(colors.PrimaryLight).Bold(true),
+			OptionSelected: lipgloss.NewStyle().Foreground(colors.SecondaryLight),
+			OptionDisabled: lipgloss.NewStyle().Foreground(colors.Base500),
+		},
+		Progress: ProgressTheme{
+			Fill:  fill,
+			Track: lipgloss.NewStyle().Background(colors.Base800),
+			Label: lipgloss.NewStyle().Foreground(colors.Base300),
+		},
+		Chart: ChartTheme{
+			Series:  fill,
+			Axis:    lipgloss.NewStyle().Foreground(colors.Base600),
+			Label:   lipgloss.NewStyle().Foreground(colors.Base400),
+			Tooltip: tooltip,
+		},
+		Tooltip: tooltip,
+		Menu: MenuTheme{
+			Bar:          lipgloss.NewStyle().Background(colors.Base800).Foreground(colors.Base50),
+			Title:        lipgloss.NewStyle(),
+			TitleActive:  lipgloss.NewStyle().Background(colors.Base700).Foreground(colors.PrimaryLight).Bold(true),
+			Menu:         lipgloss.NewStyle().Border(lipgloss.NormalBorder()).BorderForeground(colors.Base600).Background(colors.Base900),
+			Item:         lipgloss.NewStyle().Foreground(colors.Base50),
+			ItemCursor:   lipgloss.NewStyle().Background(colors.Base700).Foreground(colors.PrimaryLight).Bold(true),
+			ItemDisabled: lipgloss.NewStyle().Foreground(colors.Base500),
+			Separator:    lipgloss.NewStyle().Foreground(colors.Base600),
+			Accelerator:  lipgloss.NewStyle().Underline(true),
+			Shortcut:     lipgloss.NewStyle().Foreground(colors.Base400),
+		},
+		SplitPane: SplitPaneTheme{
+			Divider:        lipgloss.NewStyle().Foreground(colors.Base600),
+			DividerHover:   lipgloss.NewStyle().Foreground(colors.PrimaryLighter),
+			DividerFocus:   lipgloss.NewStyle().Foreground(colors.PrimaryLight),
+			DividerActive:  lipgloss.NewStyle().Foreground(colors.PrimaryLighter).Bold(true),
+			HorizontalChar: "│",
+			VerticalChar:   "─",
+		},
+		List: ListTheme{
+			Item:        lipgloss.NewStyle(),
+			Cursor:      lipgloss.NewStyle().Background(colors.Base800),
+			CursorFocus: lipgloss.NewStyle().Bold(true).Foreground(colors.PrimaryLight).Background(colors.Base700),
+			Selected:    lipgloss.NewStyle().Foreground(colors.SecondaryLight),
+			Hovered:     lipgloss.NewStyle().Background(colors.Base600),
+			Scrollbar:   lipgloss.NewStyle().Foreground(colors.Base700),
+			ScrollThumb: lipgloss.NewStyle().Foreground(colors.Base400),
+		},
+		Tree: TreeTheme{
+			Node:        lipgloss.NewStyle(),
+			Cursor:      lipgloss.NewStyle().Background(colors.Base800),
+			CursorFocus: lipgloss.NewStyle().Bold(true).Foreground(colors.PrimaryLight).Background(colors.Base700),
+			Hovered:     lipgloss.NewStyle().Background(colors.Base600),
+			Guide:       lipgloss.NewStyle().Foreground(colors.Base600),
+			Indicator:   lipgloss.NewStyle().Foreground(colors.Base400),
+		},
+		TextArea: TextAreaTheme{
+			Base:             textArea,
+			BaseFocus:        textArea.BorderForeground(colors.Base50),
+			Text:             lipgloss.NewStyle(),
+			Placeholder:      lipgloss.NewStyle().Foreground(colors.Base500),
+			Selection:        lipgloss.NewStyle().Foreground(colors.PrimaryLighter).Background(colors.PrimaryDarker),
+			LineNumber:       lipgloss.NewStyle().Foreground(colors.Base600),
+			CursorLineNumber: lipgloss.NewStyle().Foreground(colors.Base300),
+		},
+		Toast:     lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Background(colors.Base900).Padding(0, 1),
+		Selection: lipgloss.NewStyle().Background(colors.PrimaryDark).Foreground(colors.Base50),
 	}
 }